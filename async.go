@@ -0,0 +1,128 @@
+package ftest
+
+import (
+	"fmt"
+	"time"
+)
+
+// Eventually tests if cond returns true within timeout. cond is invoked every interval
+func (ass *Assertion) Eventually(cond func() bool, timeout, interval time.Duration) *Assertion {
+	ass.t.Helper()
+	if eventually(boolCheck(cond), timeout, interval) != "" {
+		ass.fail("Condition wasn't met within %v", timeout)
+	}
+	return ass
+}
+
+// Consistently tests if cond returns true every interval during a given duration
+func (ass *Assertion) Consistently(cond func() bool, duration, interval time.Duration) *Assertion {
+	ass.t.Helper()
+	if elapsed, failure := consistently(boolCheck(cond), duration, interval); failure != "" {
+		ass.fail("Condition wasn't met after %v", elapsed)
+	}
+	return ass
+}
+
+// EventuallyWith is like Eventually, but fn makes assertions with a given Assertion.
+// A failed assertion doesn't stop the test, fn is retried instead, until every assertion passes.
+// If timeout expires, the last failure is reported
+//
+//	ass.EventuallyWith(func(a *ftest.Assertion) { a.Eq(worker.Processed(), 3) }, time.Second, 10*time.Millisecond)
+func (ass *Assertion) EventuallyWith(fn func(a *Assertion), timeout, interval time.Duration) *Assertion {
+	ass.t.Helper()
+	if failure := eventually(ass.assertionCheck(fn), timeout, interval); failure != "" {
+		ass.fail("Assertions didn't pass within %v, last failure:\n%s", timeout, failure)
+	}
+	return ass
+}
+
+// ConsistentlyWith is like Consistently, but fn makes assertions with a given Assertion,
+// which all should pass on every invocation
+func (ass *Assertion) ConsistentlyWith(fn func(a *Assertion), duration, interval time.Duration) *Assertion {
+	ass.t.Helper()
+	if elapsed, failure := consistently(ass.assertionCheck(fn), duration, interval); failure != "" {
+		ass.fail("Assertions failed after %v:\n%s", elapsed, failure)
+	}
+	return ass
+}
+
+// eventually invokes check until it returns an empty string or timeout expires.
+// Returns the last failure
+func eventually(check func() string, timeout, interval time.Duration) string {
+	deadline := time.Now().Add(timeout)
+	for {
+		failure := check()
+		if failure == "" || !time.Now().Before(deadline) {
+			return failure
+		}
+		time.Sleep(interval)
+	}
+}
+
+// consistently invokes check until it fails or duration expires.
+// Returns the elapsed time and the failure
+func consistently(check func() string, duration, interval time.Duration) (time.Duration, string) {
+	start := time.Now()
+	for {
+		if failure := check(); failure != "" {
+			return time.Since(start), failure
+		}
+		if time.Since(start) >= duration {
+			return time.Since(start), ""
+		}
+		time.Sleep(interval)
+	}
+}
+
+func boolCheck(cond func() bool) func() string {
+	return func() string {
+		if cond() {
+			return ""
+		}
+		return "condition is false"
+	}
+}
+
+// retryT collects failures of an inner Assertion instead of failing the test
+type retryT struct {
+	test
+	failure string
+}
+
+// retryAbort is used to stop a function after a fatal failure of an inner Assertion
+type retryAbort struct{}
+
+func (rt *retryT) Helper() {}
+
+func (rt *retryT) Name() string { return nameOf(rt.test) }
+
+func (rt *retryT) Errorf(format string, args ...interface{}) {
+	if rt.failure != "" {
+		rt.failure += "\n"
+	}
+	rt.failure += fmt.Sprintf(format, args...)
+}
+
+func (rt *retryT) Fatalf(format string, args ...interface{}) {
+	rt.Errorf(format, args...)
+	panic(retryAbort{})
+}
+
+// assertionCheck turns fn into a check, which returns failures of assertions made by fn
+func (ass *Assertion) assertionCheck(fn func(a *Assertion)) func() string {
+	return func() (failure string) {
+		rt := &retryT{test: ass.t}
+		inner := NewLabel(rt, ass.label)
+		inner.mixedNumbers = ass.mixedNumbers
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(retryAbort); !ok {
+					panic(r)
+				}
+			}
+			failure = rt.failure
+		}()
+		fn(inner)
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package ftest_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexbyk/ftest"
+)
+
+func Test_Eventually(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	var n int32
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		atomic.StoreInt32(&n, 1)
+	}()
+	mt.ShouldPass(func() {
+		ass.Eventually(func() bool { return atomic.LoadInt32(&n) == 1 }, time.Second, time.Millisecond)
+	})
+	mt.ShouldFail("wasn't met within 20ms", func() {
+		ass.Eventually(func() bool { return false }, 20*time.Millisecond, time.Millisecond)
+	})
+}
+
+func Test_Consistently(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() {
+		ass.Consistently(func() bool { return true }, 20*time.Millisecond, time.Millisecond)
+	})
+	calls := 0
+	mt.ShouldFail("wasn't met after", func() {
+		ass.Consistently(func() bool { calls++; return calls < 3 }, time.Second, time.Millisecond)
+	})
+	ftest.New(t).Eq(calls, 3)
+}
+
+func Test_EventuallyWith(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	calls := 0
+	mt.ShouldPass(func() {
+		ass.EventuallyWith(func(a *ftest.Assertion) {
+			calls++
+			a.True(calls > 1).Eq(calls, 3)
+		}, time.Second, time.Millisecond)
+	})
+	ftest.New(t).Eq(calls, 3)
+
+	mt.ShouldFail("last failure:\n[Assertion] got: int(1), expected: int(2)", func() {
+		ass.EventuallyWith(func(a *ftest.Assertion) { a.Eq(1, 2) }, 10*time.Millisecond, time.Millisecond)
+	})
+	mt.ShouldFail("Not true\n[Assertion] Not false", func() {
+		ass.EventuallyWith(func(a *ftest.Assertion) { a.Soft().True(false).False(true) }, 0, 0)
+	})
+	mt.ShouldPass(func() {
+		ass.PanicsSubstr(func() {
+			ass.EventuallyWith(func(a *ftest.Assertion) { panic("unrelated") }, 0, 0)
+		}, "unrelated")
+	})
+}
+
+func Test_ConsistentlyWith(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() {
+		ass.ConsistentlyWith(func(a *ftest.Assertion) { a.Eq(1, 1) }, 10*time.Millisecond, time.Millisecond)
+	})
+	calls := 0
+	mt.ShouldFail("got: int(2), expected: int(1)", func() {
+		ass.ConsistentlyWith(func(a *ftest.Assertion) { calls++; a.Eq(calls, 1) }, time.Second, time.Millisecond)
+	})
+}
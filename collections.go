@@ -0,0 +1,234 @@
+package ftest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Len tests if a slice, array, map, string or channel has a given length
+func (ass *Assertion) Len(v interface{}, n int) *Assertion {
+	ass.t.Helper()
+	l, ok := length(v)
+	switch {
+	case !ok:
+		ass.fail("%T(%v) has no length", v, v)
+	case l != n:
+		ass.fail("%T(%v) has length %d, expected %d", v, v, l, n)
+	}
+	return ass
+}
+
+// Empty tests if a given argument is nil, has zero length, is a zero value or points to an empty value
+func (ass *Assertion) Empty(v interface{}) *Assertion {
+	ass.t.Helper()
+	if !isEmpty(reflect.ValueOf(v)) {
+		ass.fail("%T(%v) isn't empty", v, v)
+	}
+	return ass
+}
+
+// NotEmpty is the opposite of Empty
+func (ass *Assertion) NotEmpty(v interface{}) *Assertion {
+	ass.t.Helper()
+	if isEmpty(reflect.ValueOf(v)) {
+		ass.fail("%T(%v) is empty", v, v)
+	}
+	return ass
+}
+
+// ContainsElem tests if a slice or an array contains an element equal to elem
+func (ass *Assertion) ContainsElem(slice, elem interface{}) *Assertion {
+	ass.t.Helper()
+	elems, ok := ass.elements(slice)
+	if !ok {
+		return ass
+	}
+	for _, e := range elems {
+		if ass.deepEq(e, elem) {
+			return ass
+		}
+	}
+	ass.fail("%v doesn't contain %T(%v)", slice, elem, elem)
+	return ass
+}
+
+// ElementsMatch tests if 2 slices or arrays have equal elements, ignoring an order.
+// Duplicates are counted, so [1 1 2] doesn't match [1 2 2]
+func (ass *Assertion) ElementsMatch(got, expected interface{}) *Assertion {
+	ass.t.Helper()
+	gotElems, ok := ass.elements(got)
+	if !ok {
+		return ass
+	}
+	expectedElems, ok := ass.elements(expected)
+	if !ok {
+		return ass
+	}
+	missing, extra := ass.matchElements(gotElems, expectedElems)
+	if len(missing) > 0 || len(extra) > 0 {
+		ass.fail("got: %v, expected (in any order): %v%s", got, expected, missingExtra(missing, extra))
+	}
+	return ass
+}
+
+// Subset tests if every element of sub is present in super. Duplicates are counted.
+// For maps, tests if super has every key of sub with an equal value
+func (ass *Assertion) Subset(super, sub interface{}) *Assertion {
+	ass.t.Helper()
+	superV, subV := reflect.ValueOf(super), reflect.ValueOf(sub)
+	if superV.Kind() == reflect.Map && subV.Kind() == reflect.Map {
+		var missing []string
+		for _, k := range sortedKeys(subV) {
+			v, ok := ass.mapIndex(superV, k.Interface())
+			if !ok || !ass.deepEq(v, subV.MapIndex(k).Interface()) {
+				missing = append(missing, fmt.Sprintf("%#v: %v", k, subV.MapIndex(k)))
+			}
+		}
+		if len(missing) > 0 {
+			ass.fail("%v isn't a subset of %v\nmissing: %s", sub, super, strings.Join(missing, ", "))
+		}
+		return ass
+	}
+
+	superElems, ok := ass.elements(super)
+	if !ok {
+		return ass
+	}
+	subElems, ok := ass.elements(sub)
+	if !ok {
+		return ass
+	}
+	if missing, _ := ass.matchElements(superElems, subElems); len(missing) > 0 {
+		ass.fail("%v isn't a subset of %v%s", sub, super, missingExtra(missing, nil))
+	}
+	return ass
+}
+
+// HasKey tests if a map has a given key
+func (ass *Assertion) HasKey(m, key interface{}) *Assertion {
+	ass.t.Helper()
+	mV := reflect.ValueOf(m)
+	if mV.Kind() != reflect.Map {
+		ass.fail("%T(%v) isn't a map", m, m)
+		return ass
+	}
+	if _, ok := ass.mapIndex(mV, key); !ok {
+		ass.fail("%v doesn't have a key %T(%v)", m, key, key)
+	}
+	return ass
+}
+
+// Unique tests if a slice or an array doesn't have equal elements
+func (ass *Assertion) Unique(slice interface{}) *Assertion {
+	ass.t.Helper()
+	elems, ok := ass.elements(slice)
+	if !ok {
+		return ass
+	}
+	var dups []string
+	for i := range elems {
+		for j := i + 1; j < len(elems); j++ {
+			if ass.deepEq(elems[i], elems[j]) {
+				dups = append(dups, fmt.Sprintf("[%d] and [%d]: %v", i, j, elems[i]))
+				break
+			}
+		}
+	}
+	if len(dups) > 0 {
+		ass.fail("%v has duplicates:\n%s", slice, strings.Join(dups, "\n"))
+	}
+	return ass
+}
+
+// elements returns elements of a slice or an array. Fails if v is something else
+func (ass *Assertion) elements(v interface{}) ([]interface{}, bool) {
+	ass.t.Helper()
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return nil, true
+	case rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array:
+		ass.fail("%T(%v) isn't a slice or an array", v, v)
+		return nil, false
+	}
+	elems := make([]interface{}, rv.Len())
+	for i := range elems {
+		elems[i] = rv.Index(i).Interface()
+	}
+	return elems, true
+}
+
+// matchElements pairs equal elements of got and expected and returns elements left without a pair
+func (ass *Assertion) matchElements(got, expected []interface{}) (missing, extra []interface{}) {
+	used := make([]bool, len(got))
+	for _, e := range expected {
+		found := false
+		for i, g := range got {
+			if !used[i] && ass.deepEq(g, e) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, e)
+		}
+	}
+	for i, g := range got {
+		if !used[i] {
+			extra = append(extra, g)
+		}
+	}
+	return
+}
+
+// mapIndex looks up a key in a map using deepEq, so an int key matches an int64 one
+func (ass *Assertion) mapIndex(m reflect.Value, key interface{}) (interface{}, bool) {
+	for _, k := range m.MapKeys() {
+		if ass.deepEq(k.Interface(), key) {
+			return m.MapIndex(k).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+func missingExtra(missing, extra []interface{}) string {
+	var s string
+	if len(missing) > 0 {
+		s += fmt.Sprintf("\nmissing: %v", missing)
+	}
+	if len(extra) > 0 {
+		s += fmt.Sprintf("\nextra: %v", extra)
+	}
+	return s
+}
+
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+	})
+	return keys
+}
+
+func length(v interface{}) (int, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return rv.Len(), true
+	}
+	return 0, false
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Ptr:
+		return v.IsNil() || isEmpty(v.Elem())
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,64 @@
+package ftest_test
+
+import (
+	"testing"
+)
+
+func Test_Len(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.Len([]int{1, 2}, 2).Len("foo", 3).Len(map[int]int{}, 0).Len([]int(nil), 0) })
+	mt.ShouldFail("[]int([1 2]) has length 2, expected 3", func() { ass.Len([]int{1, 2}, 3) })
+	mt.ShouldFail("int(2) has no length", func() { ass.Len(2, 1) })
+}
+
+func Test_Empty(t *testing.T) {
+	var nilPtr *[]int
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() {
+		ass.Empty(nil).Empty("").Empty([]int{}).Empty(map[int]int(nil)).Empty(0).Empty(nilPtr).Empty(&[]int{}).Empty(addressT{})
+	})
+	mt.ShouldFail("isn't empty", func() { ass.Empty([]int{1}) })
+	mt.ShouldFail("isn't empty", func() { ass.Empty(addressT{Zip: "1"}) })
+
+	mt.ShouldPass(func() { ass.NotEmpty("foo").NotEmpty(1).NotEmpty(&[]int{1}) })
+	mt.ShouldFail("string() is empty", func() { ass.NotEmpty("") })
+}
+
+func Test_ContainsElemMethod(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.ContainsElem([]int64{1, 2}, 2).ContainsElem([2]string{"a", "b"}, "b") })
+	mt.ShouldPass(func() { ass.ContainsElem([]addressT{{Zip: "1"}}, addressT{Zip: "1"}) })
+	mt.ShouldFail("[1 2] doesn't contain int(3)", func() { ass.ContainsElem([]int{1, 2}, 3) })
+	mt.ShouldFail("isn't a slice or an array", func() { ass.ContainsElem("foo", "f") })
+}
+
+func Test_ElementsMatch(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.ElementsMatch([]int{1, 2, 2}, []int64{2, 1, 2}) })
+	mt.ShouldPass(func() { ass.ElementsMatch(nil, []int{}) })
+	mt.ShouldFail("missing: [2]\nextra: [1]", func() { ass.ElementsMatch([]int{1, 1, 2}, []int{1, 2, 2}) })
+	mt.ShouldFail("extra: [b]", func() { ass.ElementsMatch([]string{"a", "b"}, []string{"a"}) })
+}
+
+func Test_Subset(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.Subset([]int{1, 2, 3}, []int{3, 1}).Subset([]int{1}, nil) })
+	mt.ShouldFail("[1 1] isn't a subset of [1 2]\nmissing: [1]", func() { ass.Subset([]int{1, 2}, []int{1, 1}) })
+
+	super := map[string]interface{}{"a": 1, "b": []int{2}}
+	mt.ShouldPass(func() { ass.Subset(super, map[string]interface{}{"b": []int64{2}}) })
+	mt.ShouldFail(`missing: "a": 2, "c": 3`, func() { ass.Subset(super, map[string]interface{}{"a": 2, "b": []int{2}, "c": 3}) })
+}
+
+func Test_HasKey(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.HasKey(map[int64]bool{2: false}, 2) })
+	mt.ShouldFail("doesn't have a key int(3)", func() { ass.HasKey(map[int64]bool{2: false}, 3) })
+	mt.ShouldFail("isn't a map", func() { ass.HasKey([]int{1}, 0) })
+}
+
+func Test_Unique(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.Unique([]int{1, 2, 3}).Unique(nil) })
+	mt.ShouldFail("has duplicates:\n[0] and [2]: a", func() { ass.Unique([]string{"a", "b", "a"}) })
+}
@@ -0,0 +1,553 @@
+package ftest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxDiffs limits the number of differences printed in a failure message
+const maxDiffs = 20
+
+// diffContext is the number of unchanged lines around each hunk of a unified diff
+const diffContext = 3
+
+// maxDiffCells limits the size of a table, which diffLines builds for changed lines, to keep memory bounded
+const maxDiffCells = 1 << 20
+
+// difference is a single mismatch found by a differ
+type difference struct {
+	path string
+	msg  string
+}
+
+func (d difference) String() string {
+	if d.path == "" {
+		return d.msg
+	}
+	return d.path + ": " + d.msg
+}
+
+// visit is a pair of compared pointers, maps or slices. Slices over the same array can have
+// different lengths, so a length is a part of a key too
+type visit struct {
+	got, expected         uintptr
+	gotLen, expectedLen   int
+	gotType, expectedType reflect.Type
+}
+
+// differ walks two values side by side and collects differences between them.
+// A quiet differ only counts differences and never formats values, so it doesn't
+// call String methods or read values which aren't compared
+type differ struct {
+	diffs   []difference
+	visited map[visit]bool
+	max     int
+	opts    *eqOptions
+	quiet   bool
+}
+
+func (ass *Assertion) newDiffer(max int, opts ...EqOption) *differ {
+	o := &eqOptions{mixedNumbers: ass.mixedNumbers}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &differ{visited: map[visit]bool{}, max: max, opts: o}
+}
+
+func (d *differ) diff(got, expected interface{}) []difference {
+	d.walk("", reflect.ValueOf(got), reflect.ValueOf(expected))
+	return d.diffs
+}
+
+// equal reports whether got and expected are equal using the same rules as d
+func (d *differ) equal(got, expected reflect.Value) bool {
+	sub := &differ{visited: map[visit]bool{}, max: 1, opts: d.opts, quiet: true}
+	sub.walk("", got, expected)
+	return len(sub.diffs) == 0
+}
+
+// diffReport renders differences between got and expected. Returns an empty string
+// if the report doesn't add anything to the plain "got, expected" message
+func (ass *Assertion) diffReport(got, expected interface{}, opts ...EqOption) string {
+	diffs := ass.newDiffer(maxDiffs+1, opts...).diff(got, expected)
+	if len(diffs) == 0 || len(diffs) == 1 && diffs[0].path == "" && !strings.Contains(diffs[0].msg, "\n") {
+		return ""
+	}
+	lines := []string{"diff:"}
+	for i, d := range diffs {
+		if i == maxDiffs {
+			lines = append(lines, "... (more differences omitted)")
+			break
+		}
+		lines = append(lines, d.String())
+	}
+	return "\n" + strings.Join(lines, "\n")
+}
+
+func (d *differ) full() bool {
+	return d.max > 0 && len(d.diffs) >= d.max
+}
+
+func (d *differ) report(path, format string, args ...interface{}) {
+	if d.quiet {
+		d.diffs = append(d.diffs, difference{})
+		return
+	}
+	d.diffs = append(d.diffs, difference{path: path, msg: fmt.Sprintf(format, args...)})
+}
+
+func (d *differ) mismatch(path string, got, expected reflect.Value) {
+	if d.quiet {
+		d.report(path, "")
+		return
+	}
+	if got.IsValid() && expected.IsValid() && got.Type() != expected.Type() {
+		d.report(path, "got %s, expected %s", formatTyped(got), formatTyped(expected))
+		return
+	}
+	d.report(path, "got %s, expected %s", formatValue(got), formatValue(expected))
+}
+
+func (d *differ) walk(path string, got, expected reflect.Value) {
+	if d.full() {
+		return
+	}
+	got, expected = unwrap(got), unwrap(expected)
+
+	if cmp := d.opts.comparer(got, expected); cmp != nil {
+		if !got.CanInterface() || !expected.CanInterface() {
+			d.report(path, "Comparer for %v can't be applied to an unexported field", got.Type())
+			return
+		}
+		if !cmp(got.Interface(), expected.Interface()) {
+			d.mismatch(path, got, expected)
+		}
+		return
+	}
+
+	// nil, nil pointers, slices and maps of any types are equal
+	gotNil, expectedNil := isNilValue(got), isNilValue(expected)
+	if gotNil || expectedNil {
+		if gotNil != expectedNil {
+			d.mismatch(path, got, expected)
+		}
+		return
+	}
+
+	// numbers are compared by value, regardless of types
+	if isNumber(got) && isNumber(expected) {
+		if !d.numberEq(got, expected) {
+			d.mismatch(path, got, expected)
+		}
+		return
+	}
+
+	// containers of different, but compatible types are compared element by element
+	if got.Kind() != expected.Kind() || !compatibleTypes(got.Type(), expected.Type()) {
+		d.mismatch(path, got, expected)
+		return
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		v := visit{got: got.Pointer(), expected: expected.Pointer(), gotType: got.Type(), expectedType: expected.Type()}
+		if got.Kind() == reflect.Slice {
+			v.gotLen, v.expectedLen = got.Len(), expected.Len()
+		}
+		if d.visited[v] {
+			return
+		}
+		d.visited[v] = true
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr:
+		d.walk(path, got.Elem(), expected.Elem())
+	case reflect.Struct:
+		for i := 0; i < got.NumField(); i++ {
+			p := path + "." + got.Type().Field(i).Name
+			if d.opts.skipField(got.Type().Field(i), p) {
+				continue
+			}
+			d.walk(p, got.Field(i), expected.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		d.walkList(path, got, expected)
+	case reflect.Map:
+		d.walkMap(path, got, expected)
+	case reflect.String:
+		d.walkString(path, got.String(), expected.String())
+	case reflect.Func:
+		d.report(path, "functions can't be compared")
+	default:
+		if !basicEq(got, expected) {
+			d.mismatch(path, got, expected)
+		}
+	}
+}
+
+// absent reports an element which exists only in one of got and expected
+func (d *differ) absent(path string, got, expected reflect.Value) {
+	if d.quiet {
+		d.report(path, "")
+		return
+	}
+	if got.IsValid() {
+		d.report(path, "got %s, expected <none>", formatValue(got))
+		return
+	}
+	d.report(path, "got <none>, expected %s", formatValue(expected))
+}
+
+func (d *differ) walkList(path string, got, expected reflect.Value) {
+	n := got.Len()
+	if expected.Len() > n {
+		n = expected.Len()
+	}
+	for i := 0; i < n && !d.full(); i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= got.Len():
+			d.absent(p, reflect.Value{}, expected.Index(i))
+		case i >= expected.Len():
+			d.absent(p, got.Index(i), reflect.Value{})
+		default:
+			d.walk(p, got.Index(i), expected.Index(i))
+		}
+	}
+}
+
+func (d *differ) walkMap(path string, got, expected reflect.Value) {
+	type entry struct{ key, got, expected reflect.Value }
+	var entries []entry
+
+	if got.Type().Key() == expected.Type().Key() {
+		for _, k := range got.MapKeys() {
+			entries = append(entries, entry{k, got.MapIndex(k), expected.MapIndex(k)})
+		}
+		for _, k := range expected.MapKeys() {
+			if !got.MapIndex(k).IsValid() {
+				entries = append(entries, entry{k, reflect.Value{}, expected.MapIndex(k)})
+			}
+		}
+	} else {
+		// keys of different types, like int and int64, can't be looked up directly
+		expectedKeys := expected.MapKeys()
+		matched := make([]bool, len(expectedKeys))
+		for _, k := range got.MapKeys() {
+			e := entry{key: k, got: got.MapIndex(k)}
+			for i, ek := range expectedKeys {
+				if !matched[i] && d.equal(k, ek) {
+					matched[i] = true
+					e.expected = expected.MapIndex(ek)
+					break
+				}
+			}
+			entries = append(entries, e)
+		}
+		for i, k := range expectedKeys {
+			if !matched[i] {
+				entries = append(entries, entry{k, reflect.Value{}, expected.MapIndex(k)})
+			}
+		}
+	}
+	if !d.quiet {
+		sort.Slice(entries, func(i, j int) bool {
+			return fmt.Sprintf("%#v", entries[i].key) < fmt.Sprintf("%#v", entries[j].key)
+		})
+	}
+
+	for _, e := range entries {
+		if d.full() {
+			return
+		}
+		// keys are formatted only for a report
+		p := path + "[]"
+		if !d.quiet {
+			p = fmt.Sprintf("%s[%#v]", path, e.key)
+		}
+		switch {
+		case !e.got.IsValid() || !e.expected.IsValid():
+			d.absent(p, e.got, e.expected)
+		default:
+			d.walk(p, e.got, e.expected)
+		}
+	}
+}
+
+func (d *differ) walkString(path, got, expected string) {
+	if got == expected {
+		return
+	}
+	if d.quiet {
+		d.report(path, "")
+		return
+	}
+	if strings.Contains(got, "\n") || strings.Contains(expected, "\n") {
+		d.report(path, "strings differ:\n%s", unifiedDiff(expected, got))
+		return
+	}
+	d.report(path, "got %s, expected %s", strconv.Quote(got), strconv.Quote(expected))
+}
+
+func (d *differ) numberEq(got, expected reflect.Value) bool {
+	gotFloat, expectedFloat := isFloat(got), isFloat(expected)
+	if gotFloat || expectedFloat {
+		if gotFloat != expectedFloat && !d.opts.mixedNumbers {
+			return false
+		}
+		return d.opts.floatEq(toFloat(got), toFloat(expected))
+	}
+
+	gotSigned, expectedSigned := isSigned(got), isSigned(expected)
+	switch {
+	case gotSigned && expectedSigned:
+		return got.Int() == expected.Int()
+	case gotSigned:
+		return got.Int() >= 0 && uint64(got.Int()) == expected.Uint()
+	case expectedSigned:
+		return expected.Int() >= 0 && uint64(expected.Int()) == got.Uint()
+	}
+	return got.Uint() == expected.Uint()
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isFloat(v):
+		return v.Float()
+	case isSigned(v):
+		return float64(v.Int())
+	}
+	return float64(v.Uint())
+}
+
+func unwrap(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// compatibleTypes reports whether values of types a and b can be equal: the types are the same,
+// or they are containers of the same kind with numbers or interfaces in place of differing elements and keys
+func compatibleTypes(a, b reflect.Type) bool {
+	if a == b || a.Kind() == reflect.Interface || b.Kind() == reflect.Interface {
+		return true
+	}
+	if isNumberKind(a.Kind()) && isNumberKind(b.Kind()) {
+		return true
+	}
+	if a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return compatibleTypes(a.Elem(), b.Elem())
+	case reflect.Map:
+		return compatibleTypes(a.Key(), b.Key()) && compatibleTypes(a.Elem(), b.Elem())
+	}
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	return isNumberKind(v.Kind())
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func isSigned(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func basicEq(got, expected reflect.Value) bool {
+	switch got.Kind() {
+	case reflect.Bool:
+		return got.Bool() == expected.Bool()
+	case reflect.Complex64, reflect.Complex128:
+		return got.Complex() == expected.Complex()
+	case reflect.Chan, reflect.UnsafePointer:
+		return got.Pointer() == expected.Pointer()
+	}
+	return false
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func formatTyped(v reflect.Value) string {
+	return fmt.Sprintf("%v(%s)", v.Type(), formatValue(v))
+}
+
+// ----------- Line diff -----------
+
+type lineOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// diffLines returns a shortest edit script transforming a into b, based on the longest common subsequence.
+// Returns false if there are too many changed lines to build it
+func diffLines(a, b []string) ([]lineOp, bool) {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+	if len(ma)+1 > maxDiffCells/(len(mb)+1) {
+		return nil, false
+	}
+
+	ops := make([]lineOp, 0, len(a)+len(b))
+	for _, l := range a[:pre] {
+		ops = append(ops, lineOp{' ', l})
+	}
+
+	// lcs[i][j] is a length of the longest common subsequence of ma[i:] and mb[j:]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			switch {
+			case ma[i] == mb[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			ops = append(ops, lineOp{' ', ma[i]})
+			i++
+			j++
+		case i < len(ma) && (j == len(mb) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, lineOp{'-', ma[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', mb[j]})
+			j++
+		}
+	}
+
+	for _, l := range a[len(a)-suf:] {
+		ops = append(ops, lineOp{' ', l})
+	}
+	return ops, true
+}
+
+// unifiedDiff renders a line diff between expected and got in a unified format
+func unifiedDiff(expected, got string) string {
+	a, b := strings.Split(expected, "\n"), strings.Split(got, "\n")
+	ops, ok := diffLines(a, b)
+	if !ok {
+		return fmt.Sprintf("values differ, too many changed lines to show a diff (expected %d lines, got %d)", len(a), len(b))
+	}
+
+	// line numbers (1-based) of every op in expected and got
+	aNum, bNum := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	aNum[0], bNum[0] = 1, 1
+	for i, op := range ops {
+		aNum[i+1], bNum[i+1] = aNum[i], bNum[i]
+		if op.kind != '+' {
+			aNum[i+1]++
+		}
+		if op.kind != '-' {
+			bNum[i+1]++
+		}
+	}
+
+	lines := []string{"--- expected", "+++ got"}
+	for start := 0; start < len(ops); {
+		c := start
+		for c < len(ops) && ops[c].kind == ' ' {
+			c++
+		}
+		if c == len(ops) {
+			break
+		}
+
+		from := c - diffContext
+		if from < start {
+			from = start
+		}
+		end := c
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			r := end
+			for r < len(ops) && ops[r].kind == ' ' {
+				r++
+			}
+			if r == len(ops) || r-end > 2*diffContext {
+				end += diffContext
+				if end > len(ops) {
+					end = len(ops)
+				}
+				break
+			}
+			end = r
+		}
+
+		lines = append(lines, fmt.Sprintf("@@ -%d,%d +%d,%d @@",
+			aNum[from], aNum[end]-aNum[from], bNum[from], bNum[end]-bNum[from]))
+		for _, op := range ops[from:end] {
+			lines = append(lines, string(op.kind)+op.text)
+		}
+		start = end
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,123 @@
+package ftest_test
+
+import (
+	"strings"
+	"testing"
+)
+
+type addressT struct {
+	City string
+	Zip  string
+}
+
+type userT struct {
+	Name    string
+	Address *addressT
+	Tags    []string
+	secret  int
+}
+
+func Test_EqDiffStruct(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	got := []userT{{Name: "a", Address: &addressT{"X", "123"}}}
+	expected := []userT{{Name: "a", Address: &addressT{"X", "124"}}}
+	mt.ShouldFail(`[0].Address.Zip: got "123", expected "124"`, func() { ass.Eq(got, expected) })
+
+	got[0].Address.Zip = "124"
+	mt.ShouldPass(func() { ass.Eq(got, expected) })
+
+	got[0].secret = 1
+	mt.ShouldFail(`[0].secret: got 1, expected 0`, func() { ass.Eq(got, expected) })
+}
+
+func Test_EqDiffCollections(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldFail(`[2]: got <none>, expected 3`, func() { ass.Eq([]int{1, 2}, []int{1, 2, 3}) })
+	mt.ShouldFail(`[2]: got 3, expected <none>`, func() { ass.Eq([]int{1, 2, 3}, []int{1, 2}) })
+
+	got := map[string]interface{}{"a": 1, "b": []string{"x"}}
+	expected := map[string]interface{}{"a": 1, "b": []string{"y"}, "c": nil}
+	mt.ShouldFail(`["b"][0]: got "x", expected "y"`, func() { ass.Eq(got, expected) })
+	mt.ShouldFail(`["c"]: got <none>, expected nil`, func() { ass.Eq(got, expected) })
+	mt.ShouldFail(`["a"]: got string("1"), expected int(1)`, func() {
+		ass.Eq(map[string]interface{}{"a": "1"}, map[string]interface{}{"a": 1})
+	})
+}
+
+func Test_EqIncompatibleContainers(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldFail("got: []int([]), expected: []string([])", func() { ass.Eq([]int{}, []string{}) })
+	mt.ShouldFail("got: map[string]int(map[]), expected: map[int]bool(map[])", func() {
+		ass.Eq(map[string]int{}, map[int]bool{})
+	})
+	mt.ShouldFail("got: map[string]int(map[]), expected: map[string]string(map[])", func() {
+		ass.Eq(map[string]int{}, map[string]string{})
+	})
+	mt.ShouldFail("expected: *string", func() { s := ""; ass.Eq(new(int), &s) })
+	mt.ShouldFail("expected: [][]string", func() { ass.Eq([][]int{{}}, [][]string{{}}) })
+	mt.ShouldFail("expected: []ftest_test.userT", func() { ass.Eq([]addressT{}, []userT{}) })
+
+	mt.ShouldPass(func() {
+		ass.Eq([]int{}, []float64{}).Eq(map[int]uint8{1: 2}, map[int64]int{1: 2}).
+			Eq([]interface{}{1, "a"}, []interface{}{int8(1), "a"}).Eq([]interface{}{"a"}, []string{"a"}).
+			Eq([][]int{{1}}, [][]int64{{1}})
+	})
+}
+
+func Test_EqDiffMultiline(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	got := "line1\nline2\nchanged\nline4"
+	expected := "line1\nline2\nline3\nline4"
+	mt.ShouldFail("@@ -1,4 +1,4 @@\n line1\n line2\n-line3\n+changed\n line4", func() {
+		ass.Eq(got, expected)
+	})
+	mt.ShouldFail("--- expected\n+++ got", func() {
+		ass.Eq(userT{Name: got}, userT{Name: expected})
+	})
+}
+
+func Test_EqDiffLarge(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	lines := strings.Repeat("line\n", 8000)
+	mt.ShouldFail("values differ, too many changed lines to show a diff (expected 8002 lines, got 8002)", func() {
+		ass.Eq("a\n"+lines+"a", "b\n"+lines+"b")
+	})
+	// unchanged lines around changes don't count
+	mt.ShouldFail("@@ -7998,4 +7998,4 @@\n line\n line\n line\n-b\n+a", func() { ass.Eq(lines+"a", lines+"b") })
+}
+
+func Test_EqDiffCycle(t *testing.T) {
+	type node struct {
+		Val  int
+		Next *node
+	}
+	ass, mt := buildAssMt(t)
+	a, b := &node{Val: 1}, &node{Val: 1}
+	a.Next, b.Next = a, b
+	mt.ShouldPass(func() { ass.Eq(a, b) })
+	b.Val = 2
+	mt.ShouldFail(".Val: got 1, expected 2", func() { ass.Eq(a, b) })
+}
+
+type loudT struct{ calls *int }
+
+func (l loudT) String() string {
+	*l.calls++
+	return "loud"
+}
+
+func Test_PassingAssertionsDontFormat(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	calls := 0
+	l := loudT{&calls}
+	mt.ShouldPass(func() {
+		ass.NotNil(l).NotNil(&l).NotEq(l, loudT{}).Eq(l, l).
+			ContainsElem([]interface{}{1, l}, l).
+			Eq(map[loudT]string{l: "a"}, map[loudT]string{l: "a"}).
+			NotEq([]loudT{l}, []loudT{l, l})
+	})
+	if calls != 0 {
+		t.Fatalf("String was called %d times", calls)
+	}
+	mt.ShouldFail("got: ftest_test.loudT(loud), expected: <nil>(<nil>)", func() { ass.Eq(l, nil) })
+}
@@ -0,0 +1,99 @@
+package ftest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NoError checks if err is nil
+func (ass *Assertion) NoError(err error) *Assertion {
+	ass.t.Helper()
+	if err != nil {
+		ass.fail("Unexpected error:\n%s", errChain(err))
+	}
+	return ass
+}
+
+// Error checks if err isn't nil
+func (ass *Assertion) Error(err error) *Assertion {
+	ass.t.Helper()
+	if err == nil {
+		ass.fail("An error is expected, got nil")
+	}
+	return ass
+}
+
+// ErrorIs checks if any error in err's chain matches target, see errors.Is
+func (ass *Assertion) ErrorIs(err, target error) *Assertion {
+	ass.t.Helper()
+	if !errors.Is(err, target) {
+		ass.fail("Error isn't %T(%v):\n%s", target, target, errChain(err))
+	}
+	return ass
+}
+
+// ErrorAs checks if any error in err's chain can be assigned to target, and assigns it, see errors.As
+func (ass *Assertion) ErrorAs(err error, target interface{}) *Assertion {
+	ass.t.Helper()
+	if err == nil || !errors.As(err, target) {
+		ass.fail("No error assignable to %T:\n%s", target, errChain(err))
+	}
+	return ass
+}
+
+// ErrorContains checks if err isn't nil and its message contains substr
+func (ass *Assertion) ErrorContains(err error, substr string) *Assertion {
+	ass.t.Helper()
+	if err == nil || !strings.Contains(err.Error(), substr) {
+		ass.fail("Error doesn't contain \"%s\":\n%s", substr, errChain(err))
+	}
+	return ass
+}
+
+// ErrorMatches checks if err isn't nil and its message matches a regular expression
+func (ass *Assertion) ErrorMatches(err error, pattern string) *Assertion {
+	ass.t.Helper()
+	re := ass.compile(pattern)
+	if re == nil {
+		return ass
+	}
+	if err == nil || !re.MatchString(err.Error()) {
+		ass.fail("Error doesn't match /%s/:\n%s", pattern, errChain(err))
+	}
+	return ass
+}
+
+func (ass *Assertion) compile(pattern string) *regexp.Regexp {
+	ass.t.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		ass.fail("Bad regexp: %v", err)
+	}
+	return re
+}
+
+// errChain renders err and every error it wraps, one per line
+func errChain(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	var lines []string
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		lines = append(lines, fmt.Sprintf("%s%T: %v", strings.Repeat("  ", depth), err, err))
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			if inner := e.Unwrap(); inner != nil {
+				walk(inner, depth+1)
+			}
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner, depth+1)
+			}
+		}
+	}
+	walk(err, 0)
+	return strings.Join(lines, "\n")
+}
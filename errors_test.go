@@ -0,0 +1,61 @@
+package ftest_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+var errNotFound = errors.New("not found")
+
+func Test_NoError(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.NoError(nil) })
+	err := fmt.Errorf("load: %w", errNotFound)
+	mt.ShouldFail("*fmt.wrapError: load: not found\n  *errors.errorString: not found", func() { ass.NoError(err) })
+
+	mt.ShouldPass(func() { ass.Error(err) })
+	mt.ShouldFail("got nil", func() { ass.Error(nil) })
+}
+
+func Test_ErrorIs(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	err := fmt.Errorf("load: %w", errNotFound)
+	mt.ShouldPass(func() { ass.ErrorIs(err, errNotFound) })
+	mt.ShouldFail("Error isn't *errors.errorString(EOF)", func() { ass.ErrorIs(err, errors.New("EOF")) })
+	mt.ShouldFail("<nil>", func() { ass.ErrorIs(nil, errNotFound) })
+
+	joined := errors.Join(errors.New("first"), err)
+	mt.ShouldPass(func() { ass.ErrorIs(joined, errNotFound) })
+	mt.ShouldFail("  *fmt.wrapError: load: not found\n    *errors.errorString: not found", func() {
+		ass.ErrorIs(joined, fs.ErrExist)
+	})
+}
+
+func Test_ErrorAs(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	_, err := os.Open("/not/existing/file")
+	err = fmt.Errorf("open: %w", err)
+
+	var pathErr *fs.PathError
+	mt.ShouldPass(func() { ass.ErrorAs(err, &pathErr) })
+	ass.Eq(pathErr.Path, "/not/existing/file")
+
+	var linkErr *os.LinkError
+	mt.ShouldFail("No error assignable to **os.LinkError", func() { ass.ErrorAs(err, &linkErr) })
+	mt.ShouldFail("No error assignable", func() { ass.ErrorAs(nil, &linkErr) })
+}
+
+func Test_ErrorContainsMatches(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	err := fmt.Errorf("user 42: %w", errNotFound)
+	mt.ShouldPass(func() { ass.ErrorContains(err, "42: not") })
+	mt.ShouldFail(`doesn't contain "43"`, func() { ass.ErrorContains(err, "43") })
+	mt.ShouldFail(`doesn't contain`, func() { ass.ErrorContains(nil, "") })
+
+	mt.ShouldPass(func() { ass.ErrorMatches(err, `^user \d+: not`) })
+	mt.ShouldFail(`doesn't match /^\d+/`, func() { ass.ErrorMatches(err, `^\d+`) })
+	mt.ShouldFail("Bad regexp", func() { ass.ErrorMatches(err, `(`) })
+}
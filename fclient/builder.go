@@ -0,0 +1,207 @@
+package fclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestBuilder builds a single request. Its headers, query parameters and cookies are added
+// to the ones from Client.DefaultHeaders and Client.Jar, without changing the Client
+//
+//	cl.R().Header("X-Req", "1").Query("page", "2").BasicAuth("user", "pass").Put("/x", body)
+type RequestBuilder struct {
+	cl      *Client
+	headers http.Header
+	query   url.Values
+	cookies []*http.Cookie
+	auth    *url.Userinfo
+	ctx     context.Context
+	parts   []formPart
+	follow  *bool
+}
+
+// Header adds a header. It replaces a default header with the same name
+func (rb *RequestBuilder) Header(key, value string) *RequestBuilder {
+	rb.headers.Add(key, value)
+	return rb
+}
+
+// Query adds a query parameter
+func (rb *RequestBuilder) Query(key, value string) *RequestBuilder {
+	rb.query.Add(key, value)
+	return rb
+}
+
+// Cookie adds a cookie
+func (rb *RequestBuilder) Cookie(c *http.Cookie) *RequestBuilder {
+	rb.cookies = append(rb.cookies, c)
+	return rb
+}
+
+// BasicAuth sets an Authorization header with given credentials
+func (rb *RequestBuilder) BasicAuth(user, password string) *RequestBuilder {
+	rb.auth = url.UserPassword(user, password)
+	return rb
+}
+
+// Context sets a context of the request
+func (rb *RequestBuilder) Context(ctx context.Context) *RequestBuilder {
+	rb.ctx = ctx
+	return rb
+}
+
+// FollowRedirects overrides Client.FollowRedirects for the request
+//
+//	cl.R().FollowRedirects(true).PostForm("/login", form).RedirectsTo("/dashboard").CodeEq(200)
+func (rb *RequestBuilder) FollowRedirects(follow bool) *RequestBuilder {
+	rb.follow = &follow
+	return rb
+}
+
+// NewRequest creates a new request by Client.NewRequest and applies
+// headers, query parameters, cookies, credentials and a context of the builder
+func (rb *RequestBuilder) NewRequest(method, path string, body io.Reader) *http.Request {
+	rb.cl.t.Helper()
+	req := rb.cl.NewRequest(method, path, body)
+	for k, v := range rb.headers {
+		req.Header[k] = v
+	}
+	if len(rb.query) > 0 {
+		q := req.URL.Query()
+		for k, vs := range rb.query {
+			for _, v := range vs {
+				q.Add(k, v)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+		if strings.HasPrefix(req.RequestURI, "/") {
+			req.RequestURI = req.URL.RequestURI()
+		} else {
+			req.RequestURI = req.URL.String()
+		}
+	}
+	for _, c := range rb.cookies {
+		req.AddCookie(c)
+	}
+	if rb.auth != nil {
+		password, _ := rb.auth.Password()
+		req.SetBasicAuth(rb.auth.Username(), password)
+	}
+	if rb.ctx != nil {
+		req = req.WithContext(rb.ctx)
+	}
+	return req
+}
+
+// Request makes a Request with a given method.
+// A body can be []byte, string, json.RawMessage, io.Reader, encoding.BinaryMarshaler or nil
+// If the builder has form fields or files, body should be nil and a multipart form is sent instead
+func (rb *RequestBuilder) Request(method, path string, body interface{}) *Response {
+	rb.cl.t.Helper()
+	if len(rb.parts) > 0 {
+		if body != nil {
+			rb.cl.t.Fatalf("Request: a body can't be used together with form fields or files")
+		}
+		body = rb.multipartBody()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(toBytes(rb.cl.t, body))
+	}
+	follow := rb.cl.FollowRedirects
+	if rb.follow != nil {
+		follow = *rb.follow
+	}
+	return rb.cl.do(rb.NewRequest(method, path, reader), follow)
+}
+
+// RequestJSON makes a Request with v encoded to JSON. Sets a "Content-Type: application/json" header,
+// unless the builder already has a Content-Type
+func (rb *RequestBuilder) RequestJSON(method, path string, v interface{}) *Response {
+	rb.cl.t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		rb.cl.t.Fatalf("Can't convert to JSON: %v", err)
+	}
+	if rb.headers.Get("Content-Type") == "" {
+		rb.headers.Set("Content-Type", "application/json")
+	}
+	return rb.Request(method, path, data)
+}
+
+// PostForm makes a POST Request with url-encoded form values
+// and a "Content-Type: application/x-www-form-urlencoded" header
+func (rb *RequestBuilder) PostForm(path string, values url.Values) *Response {
+	rb.cl.t.Helper()
+	rb.headers.Set("Content-Type", "application/x-www-form-urlencoded")
+	return rb.Request("POST", path, values.Encode())
+}
+
+// Get makes a GET Request with nil body
+func (rb *RequestBuilder) Get(path string) *Response {
+	rb.cl.t.Helper()
+	return rb.Request("GET", path, nil)
+}
+
+// Post makes a POST Request
+func (rb *RequestBuilder) Post(path string, body interface{}) *Response {
+	rb.cl.t.Helper()
+	return rb.Request("POST", path, body)
+}
+
+// Put makes a PUT Request
+func (rb *RequestBuilder) Put(path string, body interface{}) *Response {
+	rb.cl.t.Helper()
+	return rb.Request("PUT", path, body)
+}
+
+// Patch makes a PATCH Request
+func (rb *RequestBuilder) Patch(path string, body interface{}) *Response {
+	rb.cl.t.Helper()
+	return rb.Request("PATCH", path, body)
+}
+
+// PostJSON makes a POST Request with v encoded to JSON, see RequestJSON
+func (rb *RequestBuilder) PostJSON(path string, v interface{}) *Response {
+	rb.cl.t.Helper()
+	return rb.RequestJSON("POST", path, v)
+}
+
+// PutJSON makes a PUT Request with v encoded to JSON, see RequestJSON
+func (rb *RequestBuilder) PutJSON(path string, v interface{}) *Response {
+	rb.cl.t.Helper()
+	return rb.RequestJSON("PUT", path, v)
+}
+
+// PatchJSON makes a PATCH Request with v encoded to JSON, see RequestJSON
+func (rb *RequestBuilder) PatchJSON(path string, v interface{}) *Response {
+	rb.cl.t.Helper()
+	return rb.RequestJSON("PATCH", path, v)
+}
+
+// Delete makes a DELETE Request with nil body
+func (rb *RequestBuilder) Delete(path string) *Response {
+	rb.cl.t.Helper()
+	return rb.Request("DELETE", path, nil)
+}
+
+// Options makes an OPTIONS Request with nil body
+func (rb *RequestBuilder) Options(path string) *Response {
+	rb.cl.t.Helper()
+	return rb.Request("OPTIONS", path, nil)
+}
+
+// Head makes a HEAD Request with nil body and checks that the handler didn't write a response body
+func (rb *RequestBuilder) Head(path string) *Response {
+	rb.cl.t.Helper()
+	resp := rb.Request("HEAD", path, nil)
+	if resp.Body.Len() > 0 {
+		resp.t.Fatalf("Head: handler wrote a body to a HEAD response:\n%s", resp.Body.String())
+	}
+	return resp
+}
@@ -0,0 +1,54 @@
+package fclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alexbyk/ftest"
+	"github.com/alexbyk/ftest/fclient"
+)
+
+type ctxKey struct{}
+
+func Test_RequestBuilder(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		c, _ := r.Cookie("session")
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s|%s|%s|%s:%s|%v|%v|%s",
+			r.Method, r.RequestURI, r.Header.Get("X-Req"), r.Header.Get("X-Default"),
+			user, password, c, r.Context().Value(ctxKey{}), body)
+	}
+	cl := fclient.New(t, fn)
+	cl.DefaultHeaders["X-Default"] = "default"
+	ctx := context.WithValue(context.Background(), ctxKey{}, "ctx value")
+
+	cl.R().Header("X-Req", "1").Query("page", "2").BasicAuth("u", "p").
+		Cookie(&http.Cookie{Name: "session", Value: "abc"}).Context(ctx).
+		Put("/x?a=b", "body").
+		BodyEq("PUT /x?a=b&page=2|1|default|u:p|session=abc|ctx value|body")
+
+	cl.R().Header("X-Default", "override").Get("/y").
+		BodyEq("GET /y||override|:||<nil>|")
+
+	// builder doesn't change the client
+	cl.Get("/z").BodyEq("GET /z||default|:||<nil>|")
+}
+
+func Test_RequestBuilderMethods(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "HEAD" {
+			w.Write([]byte(r.Method))
+		}
+	}
+	cl := fclient.New(t, fn)
+	ft := ftest.New(t)
+	ft.Eq(cl.R().Post("/", nil).Body.String(), "POST")
+	ft.Eq(cl.R().Patch("/", nil).Body.String(), "PATCH")
+	ft.Eq(cl.R().Delete("/").Body.String(), "DELETE")
+	ft.Eq(cl.R().Options("/").Body.String(), "OPTIONS")
+	ft.Eq(cl.R().Head("/").Body.String(), "")
+}
@@ -0,0 +1,152 @@
+package fclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/alexbyk/ftest"
+)
+
+// CookieCheck checks an attribute of a cookie, returning a description of a mismatch, if any
+type CookieCheck func(c *http.Cookie) error
+
+// CookieHTTPOnly checks if a cookie has the HttpOnly attribute
+func CookieHTTPOnly() CookieCheck {
+	return func(c *http.Cookie) error {
+		if !c.HttpOnly {
+			return errors.New("expected HttpOnly")
+		}
+		return nil
+	}
+}
+
+// CookieSecure checks if a cookie has the Secure attribute
+func CookieSecure() CookieCheck {
+	return func(c *http.Cookie) error {
+		if !c.Secure {
+			return errors.New("expected Secure")
+		}
+		return nil
+	}
+}
+
+// CookieSameSite checks the SameSite attribute of a cookie
+func CookieSameSite(mode http.SameSite) CookieCheck {
+	return func(c *http.Cookie) error {
+		if c.SameSite != mode {
+			return fmt.Errorf("got SameSite %s, expected %s", sameSiteName(c.SameSite), sameSiteName(mode))
+		}
+		return nil
+	}
+}
+
+// CookieMaxAge checks the Max-Age attribute of a cookie. A negative value means "Max-Age=0", as in http.Cookie
+func CookieMaxAge(seconds int) CookieCheck {
+	return func(c *http.Cookie) error {
+		if c.MaxAge != seconds {
+			return fmt.Errorf("got MaxAge %d, expected %d", c.MaxAge, seconds)
+		}
+		return nil
+	}
+}
+
+// CookiePath checks the Path attribute of a cookie
+func CookiePath(path string) CookieCheck {
+	return func(c *http.Cookie) error {
+		if c.Path != path {
+			return fmt.Errorf("got Path %q, expected %q", c.Path, path)
+		}
+		return nil
+	}
+}
+
+// CookieEq checks if the response sets a cookie with a given name and value
+func (resp *Response) CookieEq(name, value string) *Response {
+	resp.t.Helper()
+	c := resp.cookie("CookieEq", name)
+	ftest.NewLabel(resp.t, "CookieEq").Eqf(c.Value, value, "%s: got %q, expected %q", name, c.Value, value)
+	return resp
+}
+
+// CookieHas checks if the response sets a cookie with a given name and the cookie passes all checks
+//
+//	cl.PostForm("/login", form).CookieHas("session", fclient.CookieHTTPOnly(), fclient.CookieSameSite(http.SameSiteLaxMode))
+func (resp *Response) CookieHas(name string, checks ...CookieCheck) *Response {
+	resp.t.Helper()
+	c := resp.cookie("CookieHas", name)
+	ass := ftest.NewLabel(resp.t, "CookieHas")
+	for _, check := range checks {
+		err := check(c)
+		ass.Truef(err == nil, "%s: %v in %q", name, err, c.String())
+	}
+	return resp
+}
+
+// cookie returns the last cookie with a given name set by the response, failing the test if there is none
+func (resp *Response) cookie(label, name string) *http.Cookie {
+	resp.t.Helper()
+	var found *http.Cookie
+	names := []string{}
+	for _, c := range resp.Result().Cookies() {
+		names = append(names, c.Name)
+		if c.Name == name {
+			found = c
+		}
+	}
+	if found == nil {
+		resp.t.Fatalf("%s: the response doesn't set a cookie %q, got %q", label, name, names)
+	}
+	return found
+}
+
+// CookieValue returns a value of a cookie with a given name, stored in Jar for the "/" path
+// of the BaseURL host.
+// Fails the test if there is no such cookie
+func (cl *Client) CookieValue(name string) string {
+	cl.t.Helper()
+	if cl.Jar == nil {
+		cl.t.Fatalf("CookieValue: Jar is nil")
+	}
+	for _, c := range cl.Jar.Cookies(cl.jarURL("/")) {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	cl.t.Fatalf("CookieValue: no cookie %q in the jar", name)
+	return ""
+}
+
+// SetCookie stores a cookie in Jar, so it's sent with the following requests.
+// An empty Path means "/"
+//
+//	cl.SetCookie(&http.Cookie{Name: "session", Value: "secret"}).Get("/profile").CodeEq(200)
+func (cl *Client) SetCookie(c *http.Cookie) *Client {
+	cl.t.Helper()
+	if cl.Jar == nil {
+		cl.t.Fatalf("SetCookie: Jar is nil")
+	}
+	cookie := *c
+	if cl.BaseURL == "" {
+		cookie.Domain = ""
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	cl.Jar.SetCookies(cl.jarURL(cookie.Path), []*http.Cookie{&cookie})
+	return cl
+}
+
+func sameSiteName(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	case http.SameSiteDefaultMode:
+		return "Default"
+	}
+	return "unset"
+}
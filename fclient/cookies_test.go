@@ -1,10 +1,12 @@
 package fclient_test
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/alexbyk/ftest"
 	"github.com/alexbyk/ftest/fclient"
 )
 
@@ -68,3 +70,83 @@ func Test_EmptyJar(t *testing.T) {
 	cl.Do(cl.NewRequest("GET", "/set", nil))
 	cl.Do(cl.NewRequest("GET", "/get", nil)).BodyEq("empty")
 }
+
+func Test_CookieAssertions(t *testing.T) {
+	cookie := &http.Cookie{Name: "session", Value: "secret", Path: "/", MaxAge: 60, HttpOnly: true, SameSite: http.SameSiteLaxMode}
+	cl, mt := buildClientMt(t, makeHandlerCookie("session", cookie))
+	mt.ShouldPass(func() {
+		cl.Get("/set").CookieEq("session", "secret").
+			CookieHas("session", fclient.CookieHTTPOnly(), fclient.CookiePath("/"), fclient.CookieMaxAge(60),
+				fclient.CookieSameSite(http.SameSiteLaxMode))
+	})
+	mt.ShouldFail(`[CookieEq] session: got "secret", expected "other"`, func() { cl.Get("/set").CookieEq("session", "other") })
+	mt.ShouldFail(`CookieEq: the response doesn't set a cookie "foo", got ["session"]`, func() { cl.Get("/set").CookieEq("foo", "bar") })
+	mt.ShouldFail(`[CookieHas] session: expected Secure in "session=secret; Path=/; Max-Age=60; HttpOnly; SameSite=Lax"`, func() {
+		cl.Get("/set").CookieHas("session", fclient.CookieSecure())
+	})
+	mt.ShouldFail("session: got SameSite Lax, expected Strict", func() {
+		cl.Get("/set").CookieHas("session", fclient.CookieSameSite(http.SameSiteStrictMode))
+	})
+	mt.ShouldFail("session: got MaxAge 60, expected 0", func() { cl.Get("/set").CookieHas("session", fclient.CookieMaxAge(0)) })
+	mt.ShouldFail(`session: got Path "/", expected "/api"`, func() { cl.Get("/set").CookieHas("session", fclient.CookiePath("/api")) })
+	mt.ShouldFail("doesn't set a cookie", func() { cl.Get("/get").CookieHas("session") })
+
+	cookie.HttpOnly = false
+	mt.ShouldFail("session: expected HttpOnly", func() { cl.Get("/set").CookieHas("session", fclient.CookieHTTPOnly()) })
+}
+
+func Test_ClientCookies(t *testing.T) {
+	cookie := &http.Cookie{Name: "foo", Value: "bar", Domain: "alexbyk.com"}
+	cl, mt := buildClientMt(t, makeHandlerCookie("foo", cookie))
+	mt.ShouldFail(`CookieValue: no cookie "foo" in the jar`, func() { cl.CookieValue("foo") })
+	mt.ShouldPass(func() { cl.Get("/set") })
+	ftest.New(t).Eq(cl.CookieValue("foo"), "bar")
+
+	cl.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, c := range r.Cookies() {
+			fmt.Fprintf(w, "%s=%s;", c.Name, c.Value)
+		}
+	})
+	cl.SetCookie(&http.Cookie{Name: "foo", Value: "seeded"})
+	ftest.New(t).Eq(cl.CookieValue("foo"), "seeded")
+	cl.Get("/").BodyEq("foo=seeded;")
+
+	cl.SetCookie(&http.Cookie{Name: "foo", Value: "baz", Path: "/api"}).Get("/api/users").BodyEq("foo=baz;foo=seeded;")
+	cl.Get("/").BodyEq("foo=seeded;")
+	ftest.New(t).Eq(cl.CookieValue("foo"), "seeded")
+
+	cl.Jar = nil
+	mt.ShouldFail("SetCookie: Jar is nil", func() { cl.SetCookie(cookie) })
+	mt.ShouldFail("CookieValue: Jar is nil", func() { cl.CookieValue("foo") })
+}
+
+func Test_CookiesBaseURL(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "shared", Value: "1", Domain: "example.org"})
+			http.SetCookie(w, &http.Cookie{Name: "host", Value: "2"})
+			http.SetCookie(w, &http.Cookie{Name: "secure", Value: "3", Secure: true})
+			http.SetCookie(w, &http.Cookie{Name: "other", Value: "4", Domain: "other.org"})
+		default:
+			for _, c := range r.Cookies() {
+				fmt.Fprintf(w, "%s=%s;", c.Name, c.Value)
+			}
+		}
+	}
+	cl := fclient.New(t, fn)
+	cl.BaseURL = "https://a.example.org"
+	cl.Get("/login")
+	cl.Get("/").BodyEq("shared=1;host=2;secure=3;")
+
+	cl.BaseURL = "http://b.example.org"
+	cl.Get("/").BodyEq("shared=1;")
+	ftest.New(t).Eq(cl.CookieValue("shared"), "1")
+
+	cl.BaseURL = "http://a.example.org"
+	cl.Get("/").BodyEq("shared=1;host=2;")
+
+	cl.BaseURL = "http://example.net"
+	cl.SetCookie(&http.Cookie{Name: "seeded", Value: "5"})
+	cl.Get("/").BodyEq("seeded=5;")
+}
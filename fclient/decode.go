@@ -0,0 +1,47 @@
+package fclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// DecodeJSON decodes the response JSON into v, failing the test with the body if it can't be decoded.
+// Useful to continue a flow with a value from the response
+//
+//	var user struct{ ID int }
+//	cl.PostJSON("/users", map[string]string{"name": "foo"}).CodeEq(201).DecodeJSON(&user)
+//	cl.Get(fmt.Sprintf("/users/%d", user.ID)).CodeEq(200)
+func (resp *Response) DecodeJSON(v interface{}) *Response {
+	resp.t.Helper()
+	if err := json.Unmarshal(resp.Body.Bytes(), v); err != nil {
+		resp.t.Fatalf("DecodeJSON: can't decode the response body into %T: %v\n%s", v, err, resp.Body.String())
+	}
+	return resp
+}
+
+// DecodeJSONStrict is like DecodeJSON, but also fails if the response JSON has fields missing in v
+func (resp *Response) DecodeJSONStrict(v interface{}) *Response {
+	resp.t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(resp.Body.Bytes()))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("unexpected data after a JSON value")
+	}
+	if err != nil {
+		resp.t.Fatalf("DecodeJSONStrict: can't decode the response body into %T: %v\n%s", v, err, resp.Body.String())
+	}
+	return resp
+}
+
+// DecodeXML decodes the response XML into v, failing the test with the body if it can't be decoded
+func (resp *Response) DecodeXML(v interface{}) *Response {
+	resp.t.Helper()
+	if err := xml.Unmarshal(resp.Body.Bytes(), v); err != nil {
+		resp.t.Fatalf("DecodeXML: can't decode the response body into %T: %v\n%s", v, err, resp.Body.String())
+	}
+	return resp
+}
@@ -0,0 +1,55 @@
+package fclient_test
+
+import (
+	"testing"
+
+	"github.com/alexbyk/ftest"
+)
+
+func Test_DecodeJSON(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, userJSON))
+	var user struct {
+		ID   int
+		Name string
+	}
+	mt.ShouldPass(func() { cl.Get("/").DecodeJSON(&user).CodeEq(200) })
+	ftest.New(t).Eq(user.ID, 7).Eq(user.Name, "foo")
+
+	var wrong struct{ Name int }
+	mt.ShouldFail("DecodeJSON: can't decode the response body into *struct { Name int }", func() { cl.Get("/").DecodeJSON(&wrong) })
+
+	cl.Handler = makeBodyResp(200, "not json")
+	mt.ShouldFail("DecodeJSON: can't decode the response body into *struct { ID int; Name string }: invalid character", func() {
+		cl.Get("/").DecodeJSON(&user)
+	})
+	mt.ShouldFail("\nnot json", func() { cl.Get("/").DecodeJSON(&user) })
+}
+
+func Test_DecodeJSONStrict(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, `{"id": 7, "name": "foo"}`))
+	var user struct {
+		ID   int
+		Name string
+	}
+	mt.ShouldPass(func() { cl.Get("/").DecodeJSONStrict(&user) })
+	ftest.New(t).Eq(user.ID, 7)
+
+	var partial struct{ ID int }
+	mt.ShouldFail(`json: unknown field "name"`, func() { cl.Get("/").DecodeJSONStrict(&partial) })
+
+	cl.Handler = makeBodyResp(200, `{"id": 7} {}`)
+	mt.ShouldFail("unexpected data after a JSON value", func() { cl.Get("/").DecodeJSONStrict(&partial) })
+}
+
+func Test_DecodeXML(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, `<user id="7"><name>foo</name></user>`))
+	var user struct {
+		ID   int    `xml:"id,attr"`
+		Name string `xml:"name"`
+	}
+	mt.ShouldPass(func() { cl.Get("/").DecodeXML(&user) })
+	ftest.New(t).Eq(user.ID, 7).Eq(user.Name, "foo")
+
+	cl.Handler = makeBodyResp(200, `<user>`)
+	mt.ShouldFail("DecodeXML: can't decode the response body into", func() { cl.Get("/").DecodeXML(&user) })
+}
@@ -44,6 +44,8 @@ package fclient
 
 import (
 	"bytes"
+	"crypto/tls"
+	"encoding"
 	"encoding/json"
 	"io"
 	"log"
@@ -51,12 +53,15 @@ import (
 	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/alexbyk/ftest"
 )
 
 type test interface {
 	Fatalf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
 	Helper()
 }
 
@@ -74,80 +79,235 @@ type Client struct {
 
 	// Jar holds cookies. Can be set to nil to turn cookies off
 	Jar http.CookieJar
+
+	// FollowRedirects makes Do follow redirects, see RequestBuilder.FollowRedirects to change it for a single request
+	FollowRedirects bool
+
+	// MaxRedirects is a maximum number of redirects to follow, 10 if zero
+	MaxRedirects int
+
+	// Server is a started server if the client was built with WithServer, WithTLSServer or WithHTTP2Server.
+	// Requests are sent to it by an http.Client instead of calling Handler.ServeHTTP
+	Server *httptest.Server
+
+	httpClient *http.Client
+
+	// History holds the last requests made by the client and their responses, in order.
+	// Requests can be made from several goroutines, but History shouldn't be read until they are done
+	History   []*Exchange
+	historyMu sync.Mutex
+
+	// MaxHistory is a maximum number of exchanges kept in History, 100 if zero. A negative value turns History off
+	MaxHistory int
+
+	// Verbose makes the client log every request and response with t.Logf
+	Verbose bool
+
+	// BaseURL, like "https://tenant.example.com/api", sets a scheme and a host of requests
+	// and prefixes their paths. Cookies are stored in Jar for this host, respecting their
+	// Domain and Path. If empty, requests are made to "example.com" and a Domain of cookies is ignored
+	BaseURL string
 }
 
-// New builds a new http testing client
-func New(t test, handler http.Handler) *Client {
+// New builds a new http testing client. By default, requests are passed directly to handler.ServeHTTP,
+// options like WithServer make them go through a real network
+func New(t test, handler http.Handler, opts ...Option) *Client {
 	t.Helper()
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		log.Fatal(err)
 	}
-	return &Client{t: t, Jar: jar, Handler: handler,
+	cl := &Client{t: t, Jar: jar, Handler: handler,
 		DefaultHeaders: map[string]string{},
 	}
+	for _, opt := range opts {
+		opt(cl)
+	}
+	return cl
+}
+
+// R creates a RequestBuilder to make a single request with its own headers, query parameters and so on
+func (cl *Client) R() *RequestBuilder {
+	return &RequestBuilder{cl: cl, headers: http.Header{}, query: url.Values{}}
+}
+
+// Request makes a Request with a given method.
+// A body can be []byte, string, json.RawMessage, io.Reader, encoding.BinaryMarshaler or nil
+func (cl *Client) Request(method, path string, body interface{}) *Response {
+	cl.t.Helper()
+	return cl.R().Request(method, path, body)
 }
 
 // Get makes a GET Request with nil body
 func (cl *Client) Get(path string) *Response {
 	cl.t.Helper()
-	return cl.Do(cl.NewRequest("GET", path, nil))
+	return cl.R().Get(path)
 }
 
 // Post makes a POST Request
 func (cl *Client) Post(path string, body interface{}) *Response {
 	cl.t.Helper()
-	reader := bytes.NewReader(toBytes(cl.t, body))
-	req := cl.NewRequest("POST", path, reader)
-	return cl.Do(req)
+	return cl.R().Post(path, body)
 }
 
-func urlFromReq(req *http.Request) *url.URL {
-	u, err := url.Parse("https://example.com")
-	if err != nil {
-		panic(err)
+// Put makes a PUT Request
+func (cl *Client) Put(path string, body interface{}) *Response {
+	cl.t.Helper()
+	return cl.R().Put(path, body)
+}
+
+// Patch makes a PATCH Request
+func (cl *Client) Patch(path string, body interface{}) *Response {
+	cl.t.Helper()
+	return cl.R().Patch(path, body)
+}
+
+// PostJSON makes a POST Request with v encoded to JSON and a "Content-Type: application/json" header
+func (cl *Client) PostJSON(path string, v interface{}) *Response {
+	cl.t.Helper()
+	return cl.R().PostJSON(path, v)
+}
+
+// PutJSON makes a PUT Request with v encoded to JSON and a "Content-Type: application/json" header
+func (cl *Client) PutJSON(path string, v interface{}) *Response {
+	cl.t.Helper()
+	return cl.R().PutJSON(path, v)
+}
+
+// PatchJSON makes a PATCH Request with v encoded to JSON and a "Content-Type: application/json" header
+func (cl *Client) PatchJSON(path string, v interface{}) *Response {
+	cl.t.Helper()
+	return cl.R().PatchJSON(path, v)
+}
+
+// PostForm makes a POST Request with url-encoded form values
+// and a "Content-Type: application/x-www-form-urlencoded" header
+func (cl *Client) PostForm(path string, values url.Values) *Response {
+	cl.t.Helper()
+	return cl.R().PostForm(path, values)
+}
+
+// Delete makes a DELETE Request with nil body
+func (cl *Client) Delete(path string) *Response {
+	cl.t.Helper()
+	return cl.R().Delete(path)
+}
+
+// Options makes an OPTIONS Request with nil body
+func (cl *Client) Options(path string) *Response {
+	cl.t.Helper()
+	return cl.R().Options(path)
+}
+
+// Head makes a HEAD Request with nil body and checks that the handler didn't write a response body
+func (cl *Client) Head(path string) *Response {
+	cl.t.Helper()
+	return cl.R().Head(path)
+}
+
+// urlFromReq returns a URL which is used to store and look up cookies in Jar for a request.
+// Without BaseURL, requests to the default "example.com" host use jarURL, others use their own host
+func (cl *Client) urlFromReq(req *http.Request) *url.URL {
+	cl.t.Helper()
+	if cl.BaseURL == "" && (req.Host == "" || req.Host == "example.com") {
+		return cl.jarURL(req.URL.Path)
+	}
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return &url.URL{Scheme: scheme, Host: req.Host, Path: req.URL.Path}
+}
+
+// jarURL returns a URL which is used to store and look up cookies in Jar for a given path
+// on the host of BaseURL
+func (cl *Client) jarURL(path string) *url.URL {
+	cl.t.Helper()
+	base := cl.baseURL()
+	if base == nil {
+		return &url.URL{Scheme: "https", Host: "example.com", Path: path}
+	}
+	return &url.URL{Scheme: base.Scheme, Host: base.Host, Path: path}
+}
+
+// baseURL parses BaseURL, failing the test if it's invalid. Returns nil for an empty BaseURL
+func (cl *Client) baseURL() *url.URL {
+	cl.t.Helper()
+	if cl.BaseURL == "" {
+		return nil
+	}
+	u, err := url.Parse(cl.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		cl.t.Fatalf("BaseURL: %q isn't an absolute http(s) URL", cl.BaseURL)
 	}
-	u.Path = req.URL.Path
 	return u
 }
 
-// Do invokes the handler, passing a given argument as a request,
-// and stores cookies from the response in Jar. A ".Domain" field of every cookie
-// will be cleared
+// Do invokes the handler (or sends a request to a server, see WithServer and NewRemote), passing a given argument as a request,
+// and stores cookies from the response in Jar. If BaseURL is empty, a ".Domain" field of every cookie
+// will be cleared. If FollowRedirects is set, redirects are followed too
 func (cl *Client) Do(req *http.Request) *Response {
 	cl.t.Helper()
-	resp := NewResponse(cl.t)
-	if cl.Handler == nil {
+	return cl.do(req, cl.FollowRedirects)
+}
+
+// serve invokes the handler or sends a request by an http.Client for a single request,
+// without following redirects
+func (cl *Client) serve(req *http.Request) *Response {
+	cl.t.Helper()
+	if cl.Handler == nil && (cl.httpClient == nil || cl.Server != nil) {
 		cl.t.Fatalf("Attempt to invoke ServeHTTP on nil Handler")
 	}
-	cl.Handler.ServeHTTP(resp, req)
+	ex := cl.record(req)
+	var resp *Response
+	if cl.httpClient != nil {
+		resp = cl.roundTrip(req)
+	} else {
+		resp = NewResponse(cl.t)
+		cl.Handler.ServeHTTP(resp, req)
+	}
+	resp.Request = req
+	cl.finish(ex, resp)
 	jar := cl.Jar
 	if jar == nil {
 		return resp
 	}
 	cookies := []*http.Cookie{}
 	for _, c := range resp.Result().Cookies() {
-		c.Domain = ""
+		if cl.BaseURL == "" {
+			c.Domain = ""
+		}
 		cookies = append(cookies, c)
 	}
-	jar.SetCookies(urlFromReq(req), cookies)
+	jar.SetCookies(cl.urlFromReq(req), cookies)
 	return resp
 }
 
 // NewRequest creates a new request (r) by httptest.NewRequest,
+// then sets a host and TLS of r from cl.BaseURL for a relative path,
 // then fills r.Headers from cl.DefaultHeaders,
 // then fills cookies from cl.Jar
 // and returns r
 func (cl *Client) NewRequest(method, path string, body io.Reader) *http.Request {
 	cl.t.Helper()
+	base := cl.baseURL()
+	if base != nil && strings.HasPrefix(path, "/") {
+		path = strings.TrimSuffix(base.Path, "/") + path
+	}
 	req := httptest.NewRequest(method, path, body)
+	if base != nil && req.URL.Host == "" {
+		req.Host = base.Host
+		if base.Scheme == "https" {
+			req.TLS = &tls.ConnectionState{Version: tls.VersionTLS12, HandshakeComplete: true, ServerName: base.Hostname()}
+		}
+	}
 	if cl.Jar == nil {
 		return req
 	}
 	for k, v := range cl.DefaultHeaders {
 		req.Header.Set(k, v)
 	}
-	for _, c := range cl.Jar.Cookies(urlFromReq(req)) {
+	for _, c := range cl.Jar.Cookies(cl.urlFromReq(req)) {
 		req.AddCookie(c)
 	}
 	return req
@@ -160,6 +320,16 @@ func (cl *Client) NewRequest(method, path string, body io.Reader) *http.Request
 type Response struct {
 	*httptest.ResponseRecorder
 	t test
+
+	// Request is the last request, which produced the response
+	Request *http.Request
+
+	// Redirects holds responses with redirects which were followed to get this response, in order
+	Redirects []*Response
+
+	// HTTPResponse is the original response, if the Response was built by NewResponseFrom.
+	// Its body is already read and closed
+	HTTPResponse *http.Response
 }
 
 // NewResponse returns a Response object with default ResponseRecorder
@@ -167,6 +337,44 @@ func NewResponse(t test) *Response {
 	return &Response{t: t, ResponseRecorder: httptest.NewRecorder()}
 }
 
+// NewResponseFrom returns a Response object with a status, headers and a body of res.
+// The body of res is read and closed, res is kept as HTTPResponse
+func NewResponseFrom(t test, res *http.Response) *Response {
+	t.Helper()
+	if res.Body != nil {
+		defer res.Body.Close()
+	}
+	if res.StatusCode < 100 || res.StatusCode > 999 {
+		t.Fatalf("Invalid response status code %d", res.StatusCode)
+	}
+	resp := NewResponse(t)
+	resp.Request = res.Request
+	resp.HTTPResponse = res
+	for k, v := range res.Header {
+		resp.Header()[k] = v
+	}
+	resp.WriteHeader(res.StatusCode)
+	if res.Body != nil {
+		if _, err := io.Copy(resp, res.Body); err != nil {
+			t.Fatalf("Can't read a response body: %v", err)
+		}
+	}
+	return resp
+}
+
+// Result returns the response generated by the handler, like httptest.ResponseRecorder.Result.
+// For a Response built by NewResponseFrom, it's a copy of HTTPResponse with its protocol and trailers
+// and a body with the recorded content
+func (resp *Response) Result() *http.Response {
+	if resp.HTTPResponse == nil {
+		return resp.ResponseRecorder.Result()
+	}
+	res := *resp.HTTPResponse
+	res.Body = io.NopCloser(bytes.NewReader(resp.Body.Bytes()))
+	res.ContentLength = int64(resp.Body.Len())
+	return &res
+}
+
 // CodeEq checks if a response code is equal to
 func (resp *Response) CodeEq(expected int) *Response {
 	resp.t.Helper()
@@ -176,7 +384,7 @@ func (resp *Response) CodeEq(expected int) *Response {
 }
 
 // BodyEq checks if a response body is equal to the given argument.
-// Accepts []byte or string
+// Accepts the same types as Client.Request
 func (resp *Response) BodyEq(expected interface{}) *Response {
 	resp.t.Helper()
 	expStr := string(toBytes(resp.t, expected))
@@ -200,40 +408,78 @@ func (resp *Response) JSONEq(expected interface{}) *Response {
 	resp.t.Helper()
 
 	// Check if response is a valid json
-	var respObj interface{}
-	err := json.Unmarshal(resp.Body.Bytes(), &respObj)
-	if err != nil {
-		resp.t.Fatalf("JSONEq: response body isn't a valid JSON:\n%s", resp.Body.String())
+	respObj := resp.bodyJSON("JSONEq")
+
+	expectedObj, expectedBytes := expectedJSON(resp.t, "JSONEq", expected)
+
+	ftest.NewLabel(resp.t, "JSONEq").Eqf(respObj, expectedObj,
+		"got:\n%s\nexpected:\n%s", resp.Body.String(), expectedBytes)
+	return resp
+}
+
+// BodyMatchesGolden checks if a response body is equal to the content of a golden file
+// testdata/<TestName>/<name>.golden (see ftest.Assertion.MatchesGolden).
+// A JSON body is normalized first: keys are sorted and the document is indented
+func (resp *Response) BodyMatchesGolden(name string) *Response {
+	resp.t.Helper()
+	body := resp.Body.Bytes()
+	if json.Valid(body) {
+		body = normalizeJSON(resp.t, body)
 	}
+	ftest.NewLabel(resp.t, "BodyMatchesGolden").MatchesGolden(name, body)
+	return resp
+}
+
+// HeaderEq checks if the first http header with given name is equal to the given value
+func (resp *Response) HeaderEq(key, value string) *Response {
+	resp.t.Helper()
+	ftest.NewLabel(resp.t, "HeaderEq").
+		Eq(resp.Result().Header.Get(key), value)
+	return resp
+}
+
+// expectedJSON converts an argument of JSON assertions to a decoded JSON document.
+// A string is used as a raw JSON, other kinds are transformed to JSON
+func expectedJSON(t test, label string, expected interface{}) (interface{}, []byte) {
+	t.Helper()
 
 	// Because an order is undefined, we convert all to bytes than to interface{}
+	var err error
 	var expectedBytes []byte
 	if v, ok := expected.(string); ok {
 		expectedBytes = []byte(v)
 	} else {
 		expectedBytes, err = json.Marshal(expected)
 		if err != nil {
-			resp.t.Fatalf("Can't convert to JSON: %v", err)
+			t.Fatalf("Can't convert to JSON: %v", err)
 		}
 	}
 
 	var expectedObj interface{}
 	err = json.Unmarshal(expectedBytes, &expectedObj)
 	if err != nil {
-		resp.t.Fatalf("JSONEq: argument isn't a valid JSON %v", err)
+		t.Fatalf("%s: argument isn't a valid JSON %v", label, err)
 	}
-
-	ftest.NewLabel(resp.t, "JSONEq").Eqf(respObj, expectedObj,
-		"got:\n%s\nexpected:\n%s", resp.Body.String(), expectedBytes)
-	return resp
+	return expectedObj, expectedBytes
 }
 
-// HeaderEq checks if the first http header with given name is equal to the given value
-func (resp *Response) HeaderEq(key, value string) *Response {
-	resp.t.Helper()
-	ftest.NewLabel(resp.t, "HeaderEq").
-		Eq(resp.Result().Header.Get(key), value)
-	return resp
+func normalizeJSON(t test, data []byte) []byte {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj interface{}
+	if err := dec.Decode(&obj); err != nil {
+		t.Fatalf("Can't decode JSON: %v", err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(obj); err != nil {
+		t.Fatalf("Can't encode JSON: %v", err)
+	}
+	return buf.Bytes()
 }
 
 func toBytes(t test, in interface{}) []byte {
@@ -242,10 +488,22 @@ func toBytes(t test, in interface{}) []byte {
 	switch in := in.(type) {
 	case []byte:
 		data = in
+	case json.RawMessage:
+		data = in
 	case string:
 		data = []byte(in)
 	case nil:
 		return []byte(nil)
+	case io.Reader:
+		var err error
+		if data, err = io.ReadAll(in); err != nil {
+			t.Fatalf("Can't read a body: %v", err)
+		}
+	case encoding.BinaryMarshaler:
+		var err error
+		if data, err = in.MarshalBinary(); err != nil {
+			t.Fatalf("Can't marshal a body: %v", err)
+		}
 	default:
 		t.Fatalf("Unexpected type %T!", in)
 	}
@@ -1,7 +1,14 @@
 package fclient_test
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/alexbyk/ftest"
@@ -22,6 +29,57 @@ func Test_Request(t *testing.T) {
 		"HEAD http://foo.bar/baz")
 }
 
+func Test_Methods(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(r.Method + " " + r.URL.String() + " " + string(body)))
+	}
+	cl := fclient.New(t, fn)
+	cl.Put("/foo", "PUT BODY").BodyEq("PUT /foo PUT BODY")
+	cl.Patch("/foo", []byte("PATCH BODY")).BodyEq("PATCH /foo PATCH BODY")
+	cl.Delete("/foo").BodyEq("DELETE /foo ")
+	cl.Options("/foo").BodyEq("OPTIONS /foo ")
+	cl.Request("PROPFIND", "/foo", "x").BodyEq("PROPFIND /foo x")
+}
+
+type binaryBody struct{ err error }
+
+func (b binaryBody) MarshalBinary() ([]byte, error) { return []byte("binary"), b.err }
+
+func Test_JSONBody(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(r.Method + " " + r.Header.Get("Content-Type") + " " + string(body)))
+	}
+	cl, mt := buildClientMt(t, fn)
+	body := map[string]interface{}{"name": "foo", "price": 22}
+	mt.ShouldPass(func() {
+		cl.PostJSON("/", body).BodyEq(`POST application/json {"name":"foo","price":22}`)
+		cl.PutJSON("/", []int{1}).BodyEq(`PUT application/json [1]`)
+		cl.PatchJSON("/", nil).BodyEq(`PATCH application/json null`)
+		cl.R().Header("Content-Type", "application/merge-patch+json").PatchJSON("/", "x").
+			BodyEq(`PATCH application/merge-patch+json "x"`)
+	})
+	mt.ShouldFail("Can't convert to JSON", func() { cl.PostJSON("/", func() {}) })
+
+	mt.ShouldPass(func() {
+		cl.Post("/", json.RawMessage(`{"a":1}`)).BodyEq(`POST  {"a":1}`)
+		cl.Post("/", strings.NewReader("reader")).BodyEq("POST  reader")
+		cl.Post("/", binaryBody{}).BodyEq("POST  binary")
+	})
+	mt.ShouldFail("Can't marshal a body: boom", func() { cl.Post("/", binaryBody{errors.New("boom")}) })
+}
+
+func Test_Head(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, "body"))
+	mt.ShouldFail("handler wrote a body", func() { cl.Head("/") })
+
+	cl.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4")
+	})
+	mt.ShouldPass(func() { cl.Head("/").CodeEq(200).HeaderEq("Content-Length", "4") })
+}
+
 func Test_CodeEq(t *testing.T) {
 	cl, mt := buildClientMt(t, makeBodyResp(201, ""))
 	mt.ShouldFail("200", func() { cl.Get("/").CodeEq(201) })
@@ -122,6 +180,27 @@ func Test_DefaultHeaders(t *testing.T) {
 	ft.Eq(req.Header.Get("foo"), "FOO")
 }
 
+func Test_BaseURL(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + " " + r.RequestURI + " " + strconv.FormatBool(r.TLS != nil)))
+	}
+	cl, mt := buildClientMt(t, fn)
+	mt.ShouldPass(func() {
+		cl.Get("/users").BodyEq("example.com /users false")
+
+		cl.BaseURL = "https://tenant.example.org:8443"
+		cl.Get("/users?page=2").BodyEq("tenant.example.org:8443 /users?page=2 true")
+
+		cl.BaseURL = "http://localhost/api/"
+		cl.Get("/users").BodyEq("localhost /api/users false")
+		cl.R().Query("page", "2").Get("/users").BodyEq("localhost /api/users?page=2 false")
+		cl.Get("https://other.org/x").BodyEq("other.org https://other.org/x true")
+	})
+
+	cl.BaseURL = "/api"
+	mt.ShouldFail(`BaseURL: "/api" isn't an absolute http(s) URL`, func() { cl.Get("/users") })
+}
+
 func Test_HeaderEq(t *testing.T) {
 	cl, mt := buildClientMt(t, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("foo", "FOO")
@@ -130,11 +209,40 @@ func Test_HeaderEq(t *testing.T) {
 	mt.ShouldPass(func() { cl.Get("/").HeaderEq("foo", "FOO") })
 }
 
+func Test_BodyMatchesGolden(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv(ftest.GoldenEnv, "1")
+
+	cl, mt := buildClientMt(t, makeBodyResp(200, `{"b": [1, 2.50], "a": "<x>"}`))
+	mt.ShouldPass(func() { cl.Get("/").BodyMatchesGolden("json") })
+	content, _ := os.ReadFile(filepath.Join("testdata", "Test_BodyMatchesGolden", "json.golden"))
+	ftest.New(t).Eq(string(content), "{\n  \"a\": \"<x>\",\n  \"b\": [\n    1,\n    2.50\n  ]\n}\n")
+
+	t.Setenv(ftest.GoldenEnv, "")
+	mt.ShouldPass(func() { cl.Get("/").BodyMatchesGolden("json") })
+	cl.Handler = makeBodyResp(200, `{"a": "<y>", "b": [1, 2.50]}`)
+	mt.ShouldFail(`-  "a": "<x>",`, func() { cl.Get("/").BodyMatchesGolden("json") })
+
+	cl.Handler = makeBodyResp(200, "plain")
+	mt.ShouldFail("BodyMatchesGolden", func() { cl.Get("/").BodyMatchesGolden("text") })
+}
+
 func buildClientMt(t *testing.T, handler http.HandlerFunc) (*fclient.Client, *internal.MockT) {
 	tt := internal.NewMock(t)
 	return fclient.New(tt, handler), tt
 }
 
+func chdirTemp(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
 func makeBodyResp(code int, body string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(body))
@@ -0,0 +1,56 @@
+package fclient
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+)
+
+// formPart is a field or a file of a multipart form
+type formPart struct {
+	field    string
+	value    string
+	filename string
+	file     io.Reader
+}
+
+// FormField adds a field to a multipart form
+//
+//	cl.R().FormField("name", "foo").File("avatar", "avatar.png", reader).Post("/upload", nil)
+func (rb *RequestBuilder) FormField(field, value string) *RequestBuilder {
+	rb.parts = append(rb.parts, formPart{field: field, value: value})
+	return rb
+}
+
+// File adds a file with content from r to a multipart form
+func (rb *RequestBuilder) File(field, filename string, r io.Reader) *RequestBuilder {
+	rb.parts = append(rb.parts, formPart{field: field, filename: filename, file: r})
+	return rb
+}
+
+// multipartBody encodes form fields and files as "multipart/form-data" and sets a Content-Type header
+func (rb *RequestBuilder) multipartBody() []byte {
+	rb.cl.t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, p := range rb.parts {
+		if p.file == nil {
+			if err := w.WriteField(p.field, p.value); err != nil {
+				rb.cl.t.Fatalf("Can't write a form field: %v", err)
+			}
+			continue
+		}
+		fw, err := w.CreateFormFile(p.field, p.filename)
+		if err == nil {
+			_, err = io.Copy(fw, p.file)
+		}
+		if err != nil {
+			rb.cl.t.Fatalf("Can't write a form file: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		rb.cl.t.Fatalf("Can't write a form: %v", err)
+	}
+	rb.headers.Set("Content-Type", w.FormDataContentType())
+	return buf.Bytes()
+}
@@ -0,0 +1,45 @@
+package fclient_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alexbyk/ftest/fclient"
+)
+
+func Test_PostForm(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		fmt.Fprintf(w, "%s %s %v", r.Method, r.Header.Get("Content-Type"), r.PostForm)
+	}
+	cl := fclient.New(t, fn)
+	cl.PostForm("/login", url.Values{"user": {"foo"}, "password": {"bar"}}).
+		BodyEq("POST application/x-www-form-urlencoded map[password:[bar] user:[foo]]")
+}
+
+func Test_Multipart(t *testing.T) {
+	var fn http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		file, header, err := r.FormFile("avatar")
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		content, _ := io.ReadAll(file)
+		fmt.Fprintf(w, "%s %v %s %s", r.Method, r.MultipartForm.Value, header.Filename, content)
+	}
+	cl, mt := buildClientMt(t, fn)
+	mt.ShouldPass(func() {
+		cl.R().FormField("a", "b").FormField("a", "c").File("avatar", "x.png", strings.NewReader("PNG")).
+			Post("/upload", nil).CodeEq(200).BodyEq("POST map[a:[b c]] x.png PNG")
+		cl.R().File("avatar", "y.png", strings.NewReader("")).Put("/upload", nil).BodyEq("PUT map[] y.png ")
+	})
+	mt.ShouldFail("can't be used together", func() { cl.R().FormField("a", "b").Post("/upload", "body") })
+}
@@ -0,0 +1,141 @@
+package fclient
+
+import (
+	"mime"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alexbyk/ftest"
+)
+
+// HeaderContains checks if any value of the http header with given name contains substr
+func (resp *Response) HeaderContains(key, substr string) *Response {
+	resp.t.Helper()
+	values := resp.headerValues(key)
+	found := false
+	for _, v := range values {
+		if strings.Contains(v, substr) {
+			found = true
+			break
+		}
+	}
+	ftest.NewLabel(resp.t, "HeaderContains").Truef(found, "%s: %q doesn't contain %q", key, values, substr)
+	return resp
+}
+
+// HeaderMatches checks if any value of the http header with given name matches a regular expression
+func (resp *Response) HeaderMatches(key, pattern string) *Response {
+	resp.t.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		resp.t.Fatalf("HeaderMatches: Bad regexp: %v", err)
+	}
+	values := resp.headerValues(key)
+	found := false
+	for _, v := range values {
+		if re.MatchString(v) {
+			found = true
+			break
+		}
+	}
+	ftest.NewLabel(resp.t, "HeaderMatches").Truef(found, "%s: %q doesn't match %q", key, values, pattern)
+	return resp
+}
+
+// HeaderAbsent checks if the response has no http header with given name
+func (resp *Response) HeaderAbsent(key string) *Response {
+	resp.t.Helper()
+	values := resp.headerValues(key)
+	ftest.NewLabel(resp.t, "HeaderAbsent").Truef(len(values) == 0, "%s: expected no header, got %q", key, values)
+	return resp
+}
+
+// HeaderValues checks if all values of the http header with given name are equal to expected, in order
+//
+//	cl.Get("/").HeaderValues("Vary", []string{"Accept", "Origin"})
+func (resp *Response) HeaderValues(key string, expected []string) *Response {
+	resp.t.Helper()
+	values := resp.headerValues(key)
+	if values == nil {
+		values = []string{}
+	}
+	if expected == nil {
+		expected = []string{}
+	}
+	ftest.NewLabel(resp.t, "HeaderValues").Eqf(values, expected, "%s: got %q, expected %q", key, values, expected)
+	return resp
+}
+
+// ContentType checks the media type of the Content-Type header. Parameters of mediaType, if any,
+// should be present in the header too, but the header may have other parameters.
+// Media types and a charset are compared case-insensitively
+//
+//	cl.Get("/").ContentType("application/json")         // matches "application/json; charset=utf-8"
+//	cl.Get("/").ContentType("text/html; charset=utf-8") // requires a charset
+func (resp *Response) ContentType(mediaType string) *Response {
+	resp.t.Helper()
+	expected, expectedParams, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		resp.t.Fatalf("ContentType: bad media type %q: %v", mediaType, err)
+	}
+	header := resp.Result().Header.Get("Content-Type")
+	got, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		resp.t.Fatalf("ContentType: can't parse Content-Type %q: %v", header, err)
+	}
+
+	ass := ftest.NewLabel(resp.t, "ContentType")
+	ass.Eqf(got, expected, "got %q, expected %q", header, mediaType)
+	names := make([]string, 0, len(expectedParams))
+	for name := range expectedParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := expectedParams[name]
+		v, ok := params[name]
+		ass.Truef(ok && (v == value || name == "charset" && strings.EqualFold(v, value)),
+			"got %q, expected %s=%s", header, name, value)
+	}
+	return resp
+}
+
+// CacheControl checks if the Cache-Control header has given directives. A directive without a value,
+// like "no-store" or "max-age", checks only that the directive is present; "max-age=60" checks a value too
+//
+//	cl.Get("/").CacheControl("public", "max-age=3600")
+func (resp *Response) CacheControl(directives ...string) *Response {
+	resp.t.Helper()
+	values := resp.headerValues("Cache-Control")
+	got := parseDirectives(strings.Join(values, ","))
+
+	ass := ftest.NewLabel(resp.t, "CacheControl")
+	for _, d := range directives {
+		name, value, hasValue := strings.Cut(d, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		v, ok := got[name]
+		ass.Truef(ok, "%q doesn't have %s", values, name)
+		if ok && hasValue {
+			ass.Truef(v == strings.Trim(strings.TrimSpace(value), `"`), "%q: got %s=%s, expected %s", values, name, v, d)
+		}
+	}
+	return resp
+}
+
+func (resp *Response) headerValues(key string) []string {
+	return resp.Result().Header.Values(key)
+}
+
+// parseDirectives parses a comma separated list of directives, like "no-cache, max-age=60".
+// Names are lowercased, quotes around values are removed
+func parseDirectives(s string) map[string]string {
+	res := map[string]string{}
+	for _, d := range strings.Split(s, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(d), "=")
+		if name != "" {
+			res[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return res
+}
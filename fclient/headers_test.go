@@ -0,0 +1,69 @@
+package fclient_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func headersHandler(header http.Header) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k, values := range header {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+}
+
+func Test_HeaderAssertions(t *testing.T) {
+	cl, mt := buildClientMt(t, headersHandler(http.Header{
+		"Vary":     {"Accept", "Origin"},
+		"X-Trace":  {"abc-123"},
+		"Location": {"/users/42"},
+	}))
+	mt.ShouldPass(func() {
+		cl.Get("/").HeaderContains("Vary", "Orig").HeaderContains("x-trace", "abc").
+			HeaderMatches("Location", `^/users/\d+$`).HeaderMatches("Vary", "^Origin$").
+			HeaderAbsent("Set-Cookie").
+			HeaderValues("Vary", []string{"Accept", "Origin"}).HeaderValues("X-Missing", nil)
+	})
+	mt.ShouldFail(`[HeaderContains] Vary: ["Accept" "Origin"] doesn't contain "Host"`, func() { cl.Get("/").HeaderContains("Vary", "Host") })
+	mt.ShouldFail(`X-Missing: [] doesn't contain "a"`, func() { cl.Get("/").HeaderContains("X-Missing", "a") })
+	mt.ShouldFail(`Location: ["/users/42"] doesn't match "^/posts"`, func() { cl.Get("/").HeaderMatches("Location", "^/posts") })
+	mt.ShouldFail("Bad regexp", func() { cl.Get("/").HeaderMatches("Location", "[") })
+	mt.ShouldFail(`[HeaderAbsent] X-Trace: expected no header, got ["abc-123"]`, func() { cl.Get("/").HeaderAbsent("X-Trace") })
+	mt.ShouldFail(`Vary: got ["Accept" "Origin"], expected ["Origin" "Accept"]`, func() {
+		cl.Get("/").HeaderValues("Vary", []string{"Origin", "Accept"})
+	})
+}
+
+func Test_ContentType(t *testing.T) {
+	cl, mt := buildClientMt(t, headersHandler(http.Header{"Content-Type": {"Application/JSON; charset=UTF-8; version=2"}}))
+	mt.ShouldPass(func() {
+		cl.Get("/").ContentType("application/json").ContentType("application/json; charset=utf-8").
+			ContentType("application/json; version=2; charset=utf-8")
+	})
+	mt.ShouldFail(`[ContentType] got "Application/JSON; charset=UTF-8; version=2", expected "text/html"`, func() {
+		cl.Get("/").ContentType("text/html")
+	})
+	mt.ShouldFail("expected version=3", func() { cl.Get("/").ContentType("application/json; version=3") })
+	mt.ShouldFail("expected boundary=x", func() { cl.Get("/").ContentType("application/json; boundary=x") })
+	mt.ShouldFail("bad media type", func() { cl.Get("/").ContentType("") })
+
+	// parameters are checked in order of names, so the same one is reported every time
+	for i := 0; i < 10; i++ {
+		mt.ShouldFail("expected a=1", func() { cl.Get("/").ContentType("application/json; c=3; a=1; b=2") })
+	}
+
+	cl.Handler = headersHandler(nil)
+	mt.ShouldFail(`can't parse Content-Type ""`, func() { cl.Get("/").ContentType("text/plain") })
+}
+
+func Test_CacheControl(t *testing.T) {
+	cl, mt := buildClientMt(t, headersHandler(http.Header{"Cache-Control": {`Public, max-age=3600`, `no-cache="Set-Cookie"`}}))
+	mt.ShouldPass(func() {
+		cl.Get("/").CacheControl("public", "max-age").CacheControl("MAX-AGE=3600", `no-cache="Set-Cookie"`).CacheControl()
+	})
+	mt.ShouldFail(`["Public, max-age=3600" "no-cache=\"Set-Cookie\""] doesn't have no-store`, func() { cl.Get("/").CacheControl("no-store") })
+	mt.ShouldFail("got max-age=3600, expected max-age=60", func() { cl.Get("/").CacheControl("max-age=60") })
+}
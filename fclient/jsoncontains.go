@@ -0,0 +1,172 @@
+package fclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/alexbyk/ftest"
+)
+
+// ArrayMode defines how JSONContainsMode matches arrays
+type ArrayMode int
+
+const (
+	// ArrayExact requires arrays of the same length with matching elements in the same order
+	ArrayExact ArrayMode = iota
+	// ArrayPrefix requires expected elements to match the first elements of the response array
+	ArrayPrefix
+	// ArrayUnordered requires arrays of the same length with matching elements in any order
+	ArrayUnordered
+)
+
+// JSONContains checks if the response JSON contains expected. Expected objects are treated as subsets:
+// keys missing in them are ignored. Arrays are matched with ArrayExact mode.
+// Argument can be a string, in which case it will be used as a raw JSON, or other kind,
+// in which case it will be transformed to JSON
+//
+//	cl.Get("/user").JSONContains(`{"name": "foo", "roles": [{"id": 1}]}`)
+func (resp *Response) JSONContains(expected interface{}) *Response {
+	resp.t.Helper()
+	return resp.JSONContainsMode(expected, ArrayExact)
+}
+
+// JSONContainsMode is like JSONContains, but matches arrays with a given mode
+func (resp *Response) JSONContainsMode(expected interface{}, mode ArrayMode) *Response {
+	resp.t.Helper()
+	got := resp.bodyJSON("JSONContains")
+	expectedObj, expectedBytes := expectedJSON(resp.t, "JSONContains", expected)
+
+	m := &jsonMatcher{mode: mode}
+	m.match(nil, got, expectedObj)
+	ftest.NewLabel(resp.t, "JSONContains").Truef(len(m.diffs) == 0,
+		"%s\ngot:\n%s\nexpected:\n%s", strings.Join(m.diffs, "\n"), resp.Body.String(), expectedBytes)
+	return resp
+}
+
+// jsonMatcher checks if one decoded JSON document contains another and collects mismatches
+type jsonMatcher struct {
+	mode  ArrayMode
+	diffs []string
+}
+
+func (m *jsonMatcher) report(path []pathStep, format string, args ...interface{}) {
+	m.diffs = append(m.diffs, formatPath(path)+": "+fmt.Sprintf(format, args...))
+}
+
+func (m *jsonMatcher) matches(got, expected interface{}) bool {
+	sub := &jsonMatcher{mode: m.mode}
+	sub.match(nil, got, expected)
+	return len(sub.diffs) == 0
+}
+
+func (m *jsonMatcher) match(path []pathStep, got, expected interface{}) {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		gotObj, ok := got.(map[string]interface{})
+		if !ok {
+			m.report(path, "got %s, expected an object", compactJSON(got))
+			return
+		}
+		for _, k := range sortedKeys(expected) {
+			p := appendStep(path, pathStep{key: k})
+			v, ok := gotObj[k]
+			if !ok {
+				m.report(p, "missing, expected %s", compactJSON(expected[k]))
+				continue
+			}
+			m.match(p, v, expected[k])
+		}
+
+	case []interface{}:
+		gotArr, ok := got.([]interface{})
+		if !ok {
+			m.report(path, "got %s, expected an array", compactJSON(got))
+			return
+		}
+		m.matchArray(path, gotArr, expected)
+
+	default:
+		if !reflect.DeepEqual(got, expected) {
+			m.report(path, "got %s, expected %s", compactJSON(got), compactJSON(expected))
+		}
+	}
+}
+
+func (m *jsonMatcher) matchArray(path []pathStep, got, expected []interface{}) {
+	switch {
+	case m.mode == ArrayPrefix && len(got) < len(expected):
+		m.report(path, "got %d elements, expected at least %d", len(got), len(expected))
+		return
+	case m.mode != ArrayPrefix && len(got) != len(expected):
+		m.report(path, "got %d elements, expected %d", len(got), len(expected))
+		return
+	}
+
+	if m.mode != ArrayUnordered {
+		for i := range expected {
+			m.match(appendStep(path, pathStep{index: i, isIndex: true}), got[i], expected[i])
+		}
+		return
+	}
+
+	unmatched := false
+	for i, e := range expected {
+		found := false
+		for _, g := range got {
+			if m.matches(g, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmatched = true
+			m.report(appendStep(path, pathStep{index: i, isIndex: true}),
+				"no element matches %s", compactJSON(e))
+		}
+	}
+	if !unmatched && !m.assign(got, expected, make([]bool, len(got)), 0) {
+		m.report(path, "elements can't be matched one-to-one")
+	}
+}
+
+// assign tries to pair every expected element, starting from i, with a distinct unused got element
+func (m *jsonMatcher) assign(got, expected []interface{}, used []bool, i int) bool {
+	if i == len(expected) {
+		return true
+	}
+	for j := range got {
+		if used[j] || !m.matches(got[j], expected[i]) {
+			continue
+		}
+		used[j] = true
+		if m.assign(got, expected, used, i+1) {
+			return true
+		}
+		used[j] = false
+	}
+	return false
+}
+
+func appendStep(path []pathStep, step pathStep) []pathStep {
+	return append(path[:len(path):len(path)], step)
+}
+
+func compactJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,48 @@
+package fclient_test
+
+import (
+	"testing"
+
+	"github.com/alexbyk/ftest/fclient"
+)
+
+const userJSON = `{"id": 7, "name": "foo", "createdAt": "2018-09-29", "roles": [{"id": 1, "name": "admin"}, {"id": 2, "name": "user"}], "tags": ["a", "b", "a"]}`
+
+func Test_JSONContains(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, userJSON))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONContains(`{"name": "foo"}`).
+			JSONContains(map[string]interface{}{"id": 7, "roles": []interface{}{map[string]int{"id": 1}, map[string]int{"id": 2}}}).
+			JSONContains(`{}`)
+	})
+	mt.ShouldFail(`$.name: got "foo", expected "bar"`, func() { cl.Get("/").JSONContains(`{"name": "bar"}`) })
+	mt.ShouldFail(`$.email: missing, expected "x"`, func() { cl.Get("/").JSONContains(`{"email": "x"}`) })
+	mt.ShouldFail(`$.roles[1].name: got "user", expected "root"`, func() {
+		cl.Get("/").JSONContains(`{"roles": [{"id": 1}, {"name": "root"}]}`)
+	})
+	mt.ShouldFail(`$.roles: got 2 elements, expected 1`, func() { cl.Get("/").JSONContains(`{"roles": [{"id": 1}]}`) })
+	mt.ShouldFail(`$.name: got "foo", expected an object`, func() { cl.Get("/").JSONContains(`{"name": {}}`) })
+	mt.ShouldFail(`$.id: got 7, expected an array`, func() { cl.Get("/").JSONContains(`{"id": []}`) })
+	mt.ShouldFail("argument isn't a valid JSON", func() { cl.Get("/").JSONContains(`{`) })
+}
+
+func Test_JSONContainsMode(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, userJSON))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONContainsMode(`{"roles": [{"name": "admin"}], "tags": ["a", "b"]}`, fclient.ArrayPrefix).
+			JSONContainsMode(`{"roles": [{"id": 2}, {"id": 1}], "tags": ["a", "a", "b"]}`, fclient.ArrayUnordered).
+			JSONContainsMode(`{"roles": [{}, {"id": 1}]}`, fclient.ArrayUnordered)
+	})
+	mt.ShouldFail("$.tags: got 3 elements, expected at least 4", func() {
+		cl.Get("/").JSONContainsMode(`{"tags": ["a", "b", "a", "c"]}`, fclient.ArrayPrefix)
+	})
+	mt.ShouldFail(`$.tags[0]: got "a", expected "b"`, func() {
+		cl.Get("/").JSONContainsMode(`{"tags": ["b"]}`, fclient.ArrayPrefix)
+	})
+	mt.ShouldFail(`$.roles[1]: no element matches {"id":3}`, func() {
+		cl.Get("/").JSONContainsMode(`{"roles": [{"id": 1}, {"id": 3}]}`, fclient.ArrayUnordered)
+	})
+	mt.ShouldFail("$.tags: elements can't be matched one-to-one", func() {
+		cl.Get("/").JSONContainsMode(`{"tags": ["b", "b", "a"]}`, fclient.ArrayUnordered)
+	})
+}
@@ -0,0 +1,225 @@
+package fclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alexbyk/ftest"
+)
+
+// pathStep is a single step of a JSON path: an object key or an array index
+type pathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+var identRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// formatPath renders steps as a JSONPath expression, like $.data.items[0]["odd key"]
+func formatPath(steps []pathStep) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, s := range steps {
+		switch {
+		case s.isIndex:
+			fmt.Fprintf(&sb, "[%d]", s.index)
+		case identRe.MatchString(s.key):
+			sb.WriteString("." + s.key)
+		default:
+			fmt.Fprintf(&sb, "[%s]", strconv.Quote(s.key))
+		}
+	}
+	return sb.String()
+}
+
+// parseJSONPath parses a subset of JSONPath: $.key.other[0]['quoted key']["key"][-1].
+// A leading "$" is optional, so gjson-like paths "data.items.0.id" work too:
+// a numeric key is used as an index for arrays
+func parseJSONPath(path string) ([]pathStep, error) {
+	p := strings.TrimPrefix(path, "$")
+	if p != "" && p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+	var steps []pathStep
+	for p != "" {
+		switch p[0] {
+		case '.':
+			end := strings.IndexAny(p[1:], ".[") + 1
+			if end == 0 {
+				end = len(p)
+			}
+			if end == 1 {
+				return nil, fmt.Errorf("bad JSON path %q: empty key", path)
+			}
+			steps = append(steps, pathStep{key: p[1:end]})
+			p = p[end:]
+		case '[':
+			if len(p) > 1 && (p[1] == '\'' || p[1] == '"') {
+				// a quoted key can contain "]", so look for the closing quote first
+				end := strings.IndexByte(p[2:], p[1]) + 2
+				if end < 2 || end+1 >= len(p) || p[end+1] != ']' {
+					return nil, fmt.Errorf("bad JSON path %q: unclosed [", path)
+				}
+				steps = append(steps, pathStep{key: p[2:end]})
+				p = p[end+2:]
+				continue
+			}
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("bad JSON path %q: unclosed [", path)
+			}
+			inner := p[1:end]
+			i, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("bad JSON path %q: bad index [%s]", path, inner)
+			}
+			steps = append(steps, pathStep{index: i, isIndex: true})
+			p = p[end+1:]
+		default:
+			return nil, fmt.Errorf("bad JSON path %q: unexpected %q", path, p[0])
+		}
+	}
+	return steps, nil
+}
+
+// lookupPath walks doc along steps. Returns the value at the path, or the value at the
+// nearest resolvable prefix, and the number of resolved steps
+func lookupPath(doc interface{}, steps []pathStep) (interface{}, int) {
+	cur := doc
+	for i, s := range steps {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[s.key]
+			if s.isIndex || !ok {
+				return cur, i
+			}
+			cur = next
+		case []interface{}:
+			idx := s.index
+			if !s.isIndex {
+				var err error
+				if idx, err = strconv.Atoi(s.key); err != nil {
+					return cur, i
+				}
+			}
+			if idx < 0 {
+				idx += len(v)
+			}
+			if idx < 0 || idx >= len(v) {
+				return cur, i
+			}
+			cur = v[idx]
+		default:
+			return cur, i
+		}
+	}
+	return cur, len(steps)
+}
+
+// bodyJSON decodes a response body, failing the test if it isn't a valid JSON
+func (resp *Response) bodyJSON(label string) interface{} {
+	resp.t.Helper()
+	var obj interface{}
+	if err := json.Unmarshal(resp.Body.Bytes(), &obj); err != nil {
+		resp.t.Fatalf("%s: response body isn't a valid JSON:\n%s", label, resp.Body.String())
+	}
+	return obj
+}
+
+// jsonPath returns a value of the response JSON at a given path, failing the test
+// with the sub-document at the nearest resolvable prefix, if the path doesn't exist
+func (resp *Response) jsonPath(label, path string) interface{} {
+	resp.t.Helper()
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		resp.t.Fatalf("%s: %v", label, err)
+	}
+	v, n := lookupPath(resp.bodyJSON(label), steps)
+	if n < len(steps) {
+		resp.t.Fatalf("%s: %s not found, %s is:\n%s", label, formatPath(steps[:n+1]), formatPath(steps[:n]), toJSON(v))
+	}
+	return v
+}
+
+// JSONPathEq checks if a value of the response JSON at a given path is equal to expected.
+// Unlike JSONEq, expected is always converted to JSON, so a string means a JSON string
+//
+//	cl.Get("/items").JSONPathEq("$.data.items[0].id", 42)
+func (resp *Response) JSONPathEq(path string, expected interface{}) *Response {
+	resp.t.Helper()
+	got := resp.jsonPath("JSONPathEq", path)
+	expectedObj := fromJSON(resp.t, "JSONPathEq", expected)
+	ftest.NewLabel(resp.t, "JSONPathEq").Eqf(got, expectedObj,
+		"%s: got %s, expected %s", path, toJSON(got), toJSON(expectedObj))
+	return resp
+}
+
+// JSONPathExists checks if the response JSON has a given path
+func (resp *Response) JSONPathExists(path string) *Response {
+	resp.t.Helper()
+	resp.jsonPath("JSONPathExists", path)
+	return resp
+}
+
+// JSONPathLen checks if an array, an object or a string at a given path has n elements
+// (or characters, for strings)
+func (resp *Response) JSONPathLen(path string, n int) *Response {
+	resp.t.Helper()
+	var l int
+	switch v := resp.jsonPath("JSONPathLen", path).(type) {
+	case []interface{}:
+		l = len(v)
+	case map[string]interface{}:
+		l = len(v)
+	case string:
+		l = len([]rune(v))
+	default:
+		resp.t.Fatalf("JSONPathLen: %s has no length: %s", path, toJSON(v))
+	}
+	ftest.NewLabel(resp.t, "JSONPathLen").Eqf(l, n, "%s: got length %d, expected %d", path, l, n)
+	return resp
+}
+
+// JSONPathContains checks if an array at a given path contains an element equal to expected,
+// or if a string at a given path contains expected substring
+func (resp *Response) JSONPathContains(path string, expected interface{}) *Response {
+	resp.t.Helper()
+	ass := ftest.NewLabel(resp.t, "JSONPathContains")
+	switch v := resp.jsonPath("JSONPathContains", path).(type) {
+	case []interface{}:
+		ass.ContainsElem(v, fromJSON(resp.t, "JSONPathContains", expected))
+	case string:
+		substr, ok := expected.(string)
+		if !ok {
+			resp.t.Fatalf("JSONPathContains: %s is a string, expected a string argument, got %T", path, expected)
+		}
+		ass.Contains(v, substr)
+	default:
+		resp.t.Fatalf("JSONPathContains: %s isn't an array or a string: %s", path, toJSON(v))
+	}
+	return resp
+}
+
+// fromJSON converts v to a JSON document and decodes it back, so it can be compared with a decoded body
+func fromJSON(t test, label string, v interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("%s: can't convert to JSON: %v", label, err)
+	}
+	var obj interface{}
+	json.Unmarshal(data, &obj)
+	return obj
+}
+
+func toJSON(v interface{}) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
@@ -0,0 +1,81 @@
+package fclient_test
+
+import (
+	"testing"
+)
+
+const itemsJSON = `{"data": {"items": [{"id": 42, "name": "foo", "tags": ["a", "b"]}, {"id": 43, "odd key": true}]}, "total": 2}`
+
+func Test_JSONPathEq(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, itemsJSON))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONPathEq("$.data.items[0].id", 42).
+			JSONPathEq("$.total", 2).
+			JSONPathEq("data.items.0.name", "foo").
+			JSONPathEq("$.data.items[-1]['odd key']", true).
+			JSONPathEq(`$.data.items[1]["odd key"]`, true).
+			JSONPathEq("$.data.items[0].tags", []string{"a", "b"}).
+			JSONPathEq("$", map[string]interface{}{"data": map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"id": 42, "name": "foo", "tags": []string{"a", "b"}},
+				map[string]interface{}{"id": 43, "odd key": true},
+			}}, "total": 2})
+	})
+	mt.ShouldFail(`$.data.items[0].id: got 42, expected 43`, func() { cl.Get("/").JSONPathEq("$.data.items[0].id", 43) })
+	mt.ShouldFail(`got "foo", expected 42`, func() { cl.Get("/").JSONPathEq("$.data.items[0].name", 42) })
+	mt.ShouldFail("$.data.items[5] not found, $.data.items is:\n[\n  {\n", func() {
+		cl.Get("/").JSONPathEq("$.data.items[5].id", 42)
+	})
+	mt.ShouldFail(`$.data.items[1].name not found, $.data.items[1] is:`, func() {
+		cl.Get("/").JSONPathEq("$.data.items[1].name", "foo")
+	})
+	mt.ShouldFail(`$["odd key"] not found, $ is:`, func() { cl.Get("/").JSONPathEq("$['odd key']", true) })
+	mt.ShouldFail("bad JSON path", func() { cl.Get("/").JSONPathEq("$.data..items", 1) })
+	mt.ShouldFail("bad JSON path", func() { cl.Get("/").JSONPathEq("$.data[x]", 1) })
+	mt.ShouldFail("bad JSON path", func() { cl.Get("/").JSONPathEq("$.data[0", 1) })
+
+	cl.Handler = makeBodyResp(200, "not json")
+	mt.ShouldFail("isn't a valid JSON", func() { cl.Get("/").JSONPathEq("$", 1) })
+}
+
+func Test_JSONPathQuotedKeys(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, `{"k": {"a]b": 1, "a.b": 2, "x'y": 3, "": 4}}`))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONPathEq("$.k['a]b']", 1).
+			JSONPathEq(`$.k["a]b"]`, 1).
+			JSONPathEq("$.k['a.b']", 2).
+			JSONPathEq(`$.k["x'y"]`, 3).
+			JSONPathEq("$.k['']", 4)
+	})
+	mt.ShouldFail(`$.k["a]c"] not found`, func() { cl.Get("/").JSONPathEq("$.k['a]c']", 1) })
+	mt.ShouldFail("unclosed [", func() { cl.Get("/").JSONPathEq("$.k['a]b", 1) })
+	mt.ShouldFail("unexpected 'b'", func() { cl.Get("/").JSONPathEq("$.k['a']b", 1) })
+}
+
+func Test_JSONPathExists(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, itemsJSON))
+	mt.ShouldPass(func() { cl.Get("/").JSONPathExists("$.data.items[1]").JSONPathExists("$.total") })
+	mt.ShouldFail("$.data.items[2] not found", func() { cl.Get("/").JSONPathExists("$.data.items[2]") })
+	mt.ShouldFail("$.total.value not found", func() { cl.Get("/").JSONPathExists("$.total.value") })
+}
+
+func Test_JSONPathLen(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, itemsJSON))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONPathLen("$.data.items", 2).JSONPathLen("$.data", 1).JSONPathLen("$.data.items[0].name", 3)
+	})
+	mt.ShouldFail("$.data.items: got length 2, expected 3", func() { cl.Get("/").JSONPathLen("$.data.items", 3) })
+	mt.ShouldFail("$.total has no length", func() { cl.Get("/").JSONPathLen("$.total", 3) })
+}
+
+func Test_JSONPathContains(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, itemsJSON))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONPathContains("$.data.items[0].tags", "b").
+			JSONPathContains("$.data.items", map[string]interface{}{"id": 43, "odd key": true}).
+			JSONPathContains("$.data.items[0].name", "oo")
+	})
+	mt.ShouldFail(`doesn't contain string(c)`, func() { cl.Get("/").JSONPathContains("$.data.items[0].tags", "c") })
+	mt.ShouldFail(`"foo" doesn't contain "x"`, func() { cl.Get("/").JSONPathContains("$.data.items[0].name", "x") })
+	mt.ShouldFail("expected a string argument", func() { cl.Get("/").JSONPathContains("$.data.items[0].name", 1) })
+	mt.ShouldFail("isn't an array or a string", func() { cl.Get("/").JSONPathContains("$.total", 1) })
+}
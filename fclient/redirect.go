@@ -0,0 +1,157 @@
+package fclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/alexbyk/ftest"
+)
+
+// defaultMaxRedirects is used when Client.MaxRedirects is zero
+const defaultMaxRedirects = 10
+
+// do invokes the handler and, if follow is set, follows redirects like a browser:
+// 301, 302 and 303 turn a request into a GET without a body (HEAD stays HEAD),
+// 307 and 308 repeat the method and the body
+func (cl *Client) do(req *http.Request, follow bool) *Response {
+	cl.t.Helper()
+	if !follow {
+		return cl.serve(req)
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			cl.t.Fatalf("Do: can't read a request body: %v", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	max := cl.MaxRedirects
+	if max == 0 {
+		max = defaultMaxRedirects
+	}
+	var redirects []*Response
+	for {
+		resp := cl.serve(req)
+		target := resp.location()
+		method, keepBody := redirectMethod(resp.Code, req.Method)
+		if target == nil || method == "" {
+			resp.Redirects = redirects
+			return resp
+		}
+		redirects = append(redirects, resp)
+		if len(redirects) > max {
+			cl.t.Fatalf("Do: stopped after %d redirects: %s", max, redirectChain(redirects))
+		}
+		if !keepBody {
+			body = nil
+		}
+		req = cl.redirectRequest(req, method, target, body)
+	}
+}
+
+// redirectMethod returns a method of a request which follows a redirect with a given code,
+// or an empty string, if the code isn't a redirect
+func redirectMethod(code int, method string) (string, bool) {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther:
+		if method != "GET" && method != "HEAD" {
+			method = "GET"
+		}
+		return method, false
+	case http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return method, true
+	}
+	return "", false
+}
+
+// redirectRequest builds a request to target, copying headers of the previous request.
+// Cookies are taken from Jar again, for the target host. Credentials are dropped if a host changes
+func (cl *Client) redirectRequest(prev *http.Request, method string, target *url.URL, body []byte) *http.Request {
+	cl.t.Helper()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target.String(), reader)
+	req.RequestURI = target.RequestURI()
+	req.URL = &url.URL{Path: target.Path, RawPath: target.RawPath, RawQuery: target.RawQuery}
+	req.Header = prev.Header.Clone()
+	req.Header.Del("Cookie")
+	if body == nil {
+		req.Header.Del("Content-Type")
+		req.Header.Del("Content-Length")
+	}
+	if req.Host != prev.Host {
+		req.Header.Del("Authorization")
+	}
+	if cl.Jar != nil {
+		for _, c := range cl.Jar.Cookies(cl.urlFromReq(req)) {
+			req.AddCookie(c)
+		}
+	}
+	return req.WithContext(prev.Context())
+}
+
+// location returns the Location header resolved against the request URL, or nil if there is no header
+func (resp *Response) location() *url.URL {
+	resp.t.Helper()
+	loc := resp.Header().Get("Location")
+	if loc == "" {
+		return nil
+	}
+	u, err := url.Parse(loc)
+	if err != nil {
+		resp.t.Fatalf("Bad Location header %q: %v", loc, err)
+	}
+	if resp.Request == nil {
+		return u
+	}
+	return requestURL(resp.Request).ResolveReference(u)
+}
+
+// requestURL returns an absolute URL of a server request
+func requestURL(req *http.Request) *url.URL {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	u := *req.URL
+	u.Scheme, u.Host = scheme, req.Host
+	return &u
+}
+
+func redirectChain(redirects []*Response) string {
+	hops := []string{}
+	for _, r := range redirects {
+		hops = append(hops, r.Request.URL.RequestURI())
+	}
+	return strings.Join(hops, " -> ") + " -> " + redirects[len(redirects)-1].location().RequestURI()
+}
+
+// RedirectsTo checks if the response redirects to a given path, like "/login?next=%2F".
+// If redirects were followed, the last one is checked. An absolute URL compares a scheme and a host too
+func (resp *Response) RedirectsTo(path string) *Response {
+	resp.t.Helper()
+	last := resp
+	if len(resp.Redirects) > 0 {
+		last = resp.Redirects[len(resp.Redirects)-1]
+	}
+	target := last.location()
+	if method, _ := redirectMethod(last.Code, "GET"); method == "" || target == nil {
+		resp.t.Fatalf("RedirectsTo: got code %d without a redirect, expected a redirect to %s", last.Code, path)
+	}
+
+	got := target.RequestURI()
+	if u, err := url.Parse(path); err == nil && u.IsAbs() {
+		got = target.String()
+	}
+	ftest.NewLabel(resp.t, "RedirectsTo").Eqf(got, path, "got a redirect to %s, expected %s", got, path)
+	return resp
+}
@@ -0,0 +1,82 @@
+package fclient_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/alexbyk/ftest"
+)
+
+func redirectHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	switch r.URL.Path {
+	case "/login":
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		http.Redirect(w, r, "/dashboard?from=login", http.StatusSeeOther)
+	case "/old":
+		http.Redirect(w, r, "/login", http.StatusMovedPermanently)
+	case "/temporary":
+		http.Redirect(w, r, "echo", http.StatusTemporaryRedirect)
+	case "/loop":
+		n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+		http.Redirect(w, r, fmt.Sprintf("/loop?n=%d", n+1), http.StatusFound)
+	case "/track":
+		http.SetCookie(w, &http.Cookie{Name: "tracker", Value: "evil"})
+		http.Redirect(w, r, "https://example.com/echo", http.StatusFound)
+	case "/external-track":
+		http.Redirect(w, r, "https://other.org/track", http.StatusFound)
+	case "/external":
+		w.Header().Set("Authorization-Seen", r.Header.Get("Authorization"))
+		http.Redirect(w, r, "https://other.org/echo", http.StatusFound)
+	default:
+		fmt.Fprintf(w, "%s %s %s %q auth=%q cookie=%q", r.Method, r.Host, r.RequestURI, body, r.Header.Get("Authorization"), r.Header.Get("Cookie"))
+	}
+}
+
+func Test_FollowRedirects(t *testing.T) {
+	cl, mt := buildClientMt(t, redirectHandler)
+	mt.ShouldPass(func() {
+		cl.PostForm("/login", nil).CodeEq(303).RedirectsTo("/dashboard?from=login")
+	})
+
+	cl.FollowRedirects = true
+	mt.ShouldPass(func() {
+		resp := cl.Post("/old", "data").CodeEq(200).RedirectsTo("/dashboard?from=login").
+			BodyEq(`GET example.com /dashboard?from=login "" auth="" cookie="session=secret"`)
+		ft := ftest.New(t)
+		ft.Eq(len(resp.Redirects), 2).Eq(resp.Redirects[0].Code, 301).Eq(resp.Redirects[1].Code, 303)
+		ft.Eq(resp.Redirects[0].Request.Method, "POST").Eq(resp.Redirects[1].Request.URL.Path, "/login")
+		ft.Eq(resp.Request.URL.String(), "/dashboard?from=login")
+
+		cl.R().Header("Content-Type", "text/plain").Put("/temporary", "data").
+			BodyEq(`PUT example.com /echo "data" auth="" cookie="session=secret"`)
+		cl.Request("HEAD", "/old", nil).BodyContains("HEAD example.com /dashboard")
+		cl.R().BasicAuth("user", "pass").Get("/external").RedirectsTo("https://other.org/echo").
+			BodyEq(`GET other.org /echo "" auth="" cookie=""`)
+		cl.R().FollowRedirects(false).Get("/old").CodeEq(301).RedirectsTo("/login")
+	})
+	mt.ShouldFail("[RedirectsTo] got a redirect to /dashboard?from=login, expected /dashboard", func() {
+		cl.Get("/login").RedirectsTo("/dashboard")
+	})
+	mt.ShouldFail("RedirectsTo: got code 200 without a redirect, expected a redirect to /x", func() { cl.Get("/echo").RedirectsTo("/x") })
+	mt.ShouldFail("Do: stopped after 10 redirects: /loop -> /loop?n=1", func() { cl.Get("/loop") })
+
+	cl.MaxRedirects = 2
+	mt.ShouldFail("Do: stopped after 2 redirects: /loop -> /loop?n=1 -> /loop?n=2 -> /loop?n=3", func() { cl.Get("/loop") })
+	cl.FollowRedirects = false
+	mt.ShouldPass(func() { cl.R().FollowRedirects(true).Get("/old").CodeEq(200).RedirectsTo("/dashboard?from=login") })
+}
+
+func Test_FollowRedirects_crossHostCookies(t *testing.T) {
+	cl, mt := buildClientMt(t, redirectHandler)
+	cl.FollowRedirects = true
+	mt.ShouldPass(func() {
+		cl.Get("/external-track").RedirectsTo("https://example.com/echo").
+			BodyEq(`GET example.com /echo "" auth="" cookie=""`)
+		cl.Get("/echo").BodyEq(`GET example.com /echo "" auth="" cookie=""`)
+		cl.Get("https://other.org/echo").BodyEq(`GET other.org https://other.org/echo "" auth="" cookie="tracker=evil"`)
+	})
+}
@@ -0,0 +1,27 @@
+package fclient
+
+import (
+	"net/http"
+)
+
+// NewRemote builds a client which sends requests to a running service, like a locally started binary.
+// baseURL is used as Client.BaseURL, so relative paths are resolved against it
+//
+//	cl := fclient.NewRemote(t, "http://localhost:8080/api")
+//	cl.Get("/health").CodeEq(200)
+func NewRemote(t test, baseURL string) *Client {
+	t.Helper()
+	cl := NewTransport(t, http.DefaultTransport)
+	cl.BaseURL = baseURL
+	cl.baseURL()
+	return cl
+}
+
+// NewTransport builds a client which sends requests by a given http.RoundTripper.
+// Requests are made to the host of Client.BaseURL or to "example.com" if it's empty
+func NewTransport(t test, rt http.RoundTripper) *Client {
+	t.Helper()
+	cl := New(t, nil)
+	cl.httpClient = newHTTPClient(rt)
+	return cl
+}
@@ -0,0 +1,94 @@
+package fclient_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexbyk/ftest"
+	"github.com/alexbyk/ftest/fclient"
+	"github.com/alexbyk/ftest/internal"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return fn(req) }
+
+func Test_NewRemote(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(protoHandler))
+	defer srv.Close()
+
+	cl := fclient.NewRemote(t, srv.URL+"/api")
+	host := strings.TrimPrefix(srv.URL, "http://")
+	cl.Post("/x", "body").CodeEq(200).BodyEq(`HTTP/1.1 POST ` + host + ` /api/x tls=false "body" `)
+	cl.Get("/x").BodyEq(`HTTP/1.1 GET ` + host + ` /api/x tls=false "" visited=1`)
+
+	redirects := httptest.NewServer(http.HandlerFunc(redirectHandler))
+	defer redirects.Close()
+	cl = fclient.NewRemote(t, redirects.URL)
+	cl.FollowRedirects = true
+	cl.Get("/old").RedirectsTo("/dashboard?from=login").BodyContains(`cookie="session=secret"`)
+
+	mt := internal.NewMock(t)
+	mt.ShouldFail(`BaseURL: "localhost:8080" isn't an absolute http(s) URL`, func() { fclient.NewRemote(mt, "localhost:8080") })
+	srv.Close()
+	mt.ShouldFail("Do: Get", func() { fclient.NewRemote(mt, srv.URL).Get("/") })
+}
+
+func Test_NewTransport(t *testing.T) {
+	var got *http.Request
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		return &http.Response{
+			StatusCode: 201,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id": 1}`)),
+			Request:    req,
+		}, nil
+	})
+	cl := fclient.NewTransport(t, rt)
+	cl.R().Header("X-Req", "1").Query("a", "b").PostJSON("/users", map[string]string{"name": "foo"}).
+		CodeEq(201).ContentType("application/json").JSONEq(`{"id": 1}`)
+	ass := ftest.New(t)
+	ass.Eq(got.URL.String(), "http://example.com/users?a=b").Eq(got.Method, "POST").Eq(got.Header.Get("X-Req"), "1")
+	body, _ := io.ReadAll(got.Body)
+	ass.Eq(string(body), `{"name":"foo"}`)
+
+	cl.BaseURL = "https://api.example.org/v1"
+	cl.Get("/users").CodeEq(201)
+	ass.Eq(got.URL.String(), "https://api.example.org/v1/users")
+}
+
+func Test_NewResponseFrom(t *testing.T) {
+	req := httptest.NewRequest("GET", "/x", nil)
+	resp := fclient.NewResponseFrom(t, &http.Response{
+		StatusCode: 404,
+		Header:     http.Header{"X-Foo": {"a", "b"}},
+		Body:       io.NopCloser(strings.NewReader("not found")),
+		Request:    req,
+	})
+	resp.CodeEq(404).HeaderValues("X-Foo", []string{"a", "b"}).BodyEq("not found")
+	ftest.New(t).Eq(resp.Request, req)
+
+	resp = fclient.NewResponseFrom(t, &http.Response{
+		StatusCode: 200,
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+		Trailer:    http.Header{"X-Checksum": {"abc"}},
+		Body:       io.NopCloser(strings.NewReader("ok")),
+	})
+	res := resp.Result()
+	body, _ := io.ReadAll(res.Body)
+	ftest.New(t).Eq(res.Proto, "HTTP/2.0").Eq(res.Trailer.Get("X-Checksum"), "abc").Eq(string(body), "ok").
+		Eq(resp.Result().StatusCode, 200)
+
+	mt := internal.NewMock(t)
+	for _, code := range []int{0, 42, 1000} {
+		mt.ShouldFail(fmt.Sprintf("Invalid response status code %d", code), func() {
+			fclient.NewResponseFrom(mt, &http.Response{StatusCode: code, Body: http.NoBody})
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package fclient
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alexbyk/ftest"
+)
+
+// JSONSchema checks if the response JSON is valid against a JSON Schema (draft 2020-12).
+// Argument can be a string, in which case it will be used as a raw JSON, or other kind,
+// in which case it will be transformed to JSON.
+//
+// Supported keywords: type, enum, const, properties, patternProperties, additionalProperties,
+// required, prefixItems, items, minItems, maxItems, uniqueItems, minLength, maxLength, pattern,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, allOf, anyOf, oneOf, not
+// and $ref within the document, like "#/$defs/user". Other keywords are ignored.
+// Every violation is reported with its instance path
+//
+//	cl.Get("/user").JSONSchema(`{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`)
+func (resp *Response) JSONSchema(schema interface{}) *Response {
+	resp.t.Helper()
+	got := resp.bodyJSON("JSONSchema")
+	root, _ := expectedJSON(resp.t, "JSONSchema", schema)
+
+	v := &schemaValidator{root: root, active: map[string]bool{}}
+	v.validate(nil, got, root)
+	if v.err != nil {
+		resp.t.Fatalf("JSONSchema: invalid schema: %v", v.err)
+	}
+	ftest.NewLabel(resp.t, "JSONSchema").Truef(len(v.errs) == 0,
+		"response doesn't match the schema:\n%s\ngot:\n%s", strings.Join(v.errs, "\n"), resp.Body.String())
+	return resp
+}
+
+// schemaValidator validates a decoded JSON document against a decoded schema, collecting violations.
+// err holds the first problem with the schema itself
+type schemaValidator struct {
+	root   interface{}
+	errs   []string
+	err    error
+	active map[string]bool // $refs being resolved for an instance path, to detect loops
+}
+
+func (v *schemaValidator) report(path []pathStep, format string, args ...interface{}) {
+	v.errs = append(v.errs, formatPath(path)+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *schemaValidator) invalid(format string, args ...interface{}) {
+	if v.err == nil {
+		v.err = fmt.Errorf(format, args...)
+	}
+}
+
+// valid checks an instance against a schema without reporting violations
+func (v *schemaValidator) valid(path []pathStep, inst, schema interface{}) bool {
+	sub := &schemaValidator{root: v.root, active: v.active}
+	sub.validate(path, inst, schema)
+	if sub.err != nil {
+		v.invalid("%v", sub.err)
+	}
+	return len(sub.errs) == 0
+}
+
+func (v *schemaValidator) validate(path []pathStep, inst, schema interface{}) {
+	var s map[string]interface{}
+	switch schema := schema.(type) {
+	case bool:
+		if !schema {
+			v.report(path, "not allowed by a false schema")
+		}
+		return
+	case map[string]interface{}:
+		s = schema
+	default:
+		v.invalid("schema must be an object or a boolean, got %s", compactJSON(schema))
+		return
+	}
+
+	if ref, ok := s["$ref"]; ok {
+		v.ref(path, inst, ref)
+	}
+	if t, ok := s["type"]; ok {
+		v.checkType(path, inst, t)
+	}
+	if enum, ok := s["enum"]; ok {
+		v.checkEnum(path, inst, enum)
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(inst, c) {
+		v.report(path, "got %s, expected %s", compactJSON(inst), compactJSON(c))
+	}
+
+	switch inst := inst.(type) {
+	case map[string]interface{}:
+		v.object(path, inst, s)
+	case []interface{}:
+		v.array(path, inst, s)
+	case string:
+		v.string(path, inst, s)
+	case float64:
+		v.number(path, inst, s)
+	}
+
+	v.combinators(path, inst, s)
+}
+
+func (v *schemaValidator) ref(path []pathStep, inst, ref interface{}) {
+	r, ok := ref.(string)
+	if !ok {
+		v.invalid("$ref must be a string, got %s", compactJSON(ref))
+		return
+	}
+	key := r + " " + formatPath(path)
+	if v.active[key] {
+		v.invalid("circular $ref %q", r)
+		return
+	}
+	target, ok := v.resolve(r)
+	if !ok {
+		return
+	}
+	v.active[key] = true
+	v.validate(path, inst, target)
+	delete(v.active, key)
+}
+
+// resolve finds a schema by a JSON pointer reference within the document, like "#/$defs/user"
+func (v *schemaValidator) resolve(ref string) (interface{}, bool) {
+	if ref == "#" {
+		return v.root, true
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		v.invalid("unsupported $ref %q: only references within the document are supported", ref)
+		return nil, false
+	}
+	pointer, err := url.PathUnescape(ref[2:])
+	if err != nil {
+		v.invalid("bad $ref %q: %v", ref, err)
+		return nil, false
+	}
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	cur := v.root
+	for _, tok := range strings.Split(pointer, "/") {
+		tok = unescape.Replace(tok)
+		var ok bool
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			cur, ok = c[tok]
+		case []interface{}:
+			var i int
+			i, err = strconv.Atoi(tok)
+			if ok = err == nil && i >= 0 && i < len(c); ok {
+				cur = c[i]
+			}
+		}
+		if !ok {
+			v.invalid("can't resolve $ref %q", ref)
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+var schemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true, "number": true, "integer": true, "string": true,
+}
+
+func (v *schemaValidator) checkType(path []pathStep, inst, t interface{}) {
+	var types []string
+	switch t := t.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, x := range t {
+			s, _ := x.(string)
+			types = append(types, s)
+		}
+	}
+	if len(types) == 0 {
+		v.invalid("type must be a string or an array of strings, got %s", compactJSON(t))
+		return
+	}
+	for _, name := range types {
+		if !schemaTypes[name] {
+			v.invalid("unknown type %s", compactJSON(name))
+			return
+		}
+		if name == jsonType(inst) || name == "number" && jsonType(inst) == "integer" {
+			return
+		}
+	}
+	v.report(path, "got %s %s, expected %s", jsonType(inst), compactJSON(inst), strings.Join(types, " or "))
+}
+
+func (v *schemaValidator) checkEnum(path []pathStep, inst, enum interface{}) {
+	values, ok := enum.([]interface{})
+	if !ok {
+		v.invalid("enum must be an array, got %s", compactJSON(enum))
+		return
+	}
+	for _, e := range values {
+		if reflect.DeepEqual(inst, e) {
+			return
+		}
+	}
+	v.report(path, "got %s, expected one of %s", compactJSON(inst), compactJSON(enum))
+}
+
+func (v *schemaValidator) object(path []pathStep, inst map[string]interface{}, s map[string]interface{}) {
+	if req, ok := s["required"]; ok {
+		names, ok := req.([]interface{})
+		if !ok {
+			v.invalid("required must be an array, got %s", compactJSON(req))
+		}
+		for _, n := range names {
+			name, ok := n.(string)
+			if !ok {
+				v.invalid("required must contain strings, got %s", compactJSON(n))
+				continue
+			}
+			if _, ok := inst[name]; !ok {
+				v.report(appendStep(path, pathStep{key: name}), "missing required property")
+			}
+		}
+	}
+
+	props := v.schemaMap(s, "properties")
+	patterns := v.schemaMap(s, "patternProperties")
+	additional, hasAdditional := s["additionalProperties"]
+
+	for _, k := range sortedKeys(inst) {
+		p := appendStep(path, pathStep{key: k})
+		matched := false
+		if sub, ok := props[k]; ok {
+			matched = true
+			v.validate(p, inst[k], sub)
+		}
+		for _, pattern := range sortedKeys(patterns) {
+			if re := v.compile(pattern); re != nil && re.MatchString(k) {
+				matched = true
+				v.validate(p, inst[k], patterns[pattern])
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if additional == false {
+			v.report(p, "additional property isn't allowed")
+			continue
+		}
+		v.validate(p, inst[k], additional)
+	}
+}
+
+func (v *schemaValidator) array(path []pathStep, inst []interface{}, s map[string]interface{}) {
+	if n, ok := v.count(s, "minItems"); ok && len(inst) < n {
+		v.report(path, "got %d items, expected at least %d", len(inst), n)
+	}
+	if n, ok := v.count(s, "maxItems"); ok && len(inst) > n {
+		v.report(path, "got %d items, expected at most %d", len(inst), n)
+	}
+
+	prefix := v.schemaList(s, "prefixItems")
+	for i, sub := range prefix {
+		if i < len(inst) {
+			v.validate(appendStep(path, pathStep{index: i, isIndex: true}), inst[i], sub)
+		}
+	}
+	if items, ok := s["items"]; ok {
+		for i := len(prefix); i < len(inst); i++ {
+			v.validate(appendStep(path, pathStep{index: i, isIndex: true}), inst[i], items)
+		}
+	}
+
+	if s["uniqueItems"] == true {
+		for i := range inst {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(inst[i], inst[j]) {
+					v.report(appendStep(path, pathStep{index: i, isIndex: true}), "duplicates item %d", j)
+					break
+				}
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) string(path []pathStep, inst string, s map[string]interface{}) {
+	l := utf8.RuneCountInString(inst)
+	if n, ok := v.count(s, "minLength"); ok && l < n {
+		v.report(path, "got length %d, expected at least %d", l, n)
+	}
+	if n, ok := v.count(s, "maxLength"); ok && l > n {
+		v.report(path, "got length %d, expected at most %d", l, n)
+	}
+	if p, ok := s["pattern"]; ok {
+		pattern, ok := p.(string)
+		if !ok {
+			v.invalid("pattern must be a string, got %s", compactJSON(p))
+			return
+		}
+		if re := v.compile(pattern); re != nil && !re.MatchString(inst) {
+			v.report(path, "%s doesn't match pattern %s", compactJSON(inst), compactJSON(pattern))
+		}
+	}
+}
+
+func (v *schemaValidator) number(path []pathStep, inst float64, s map[string]interface{}) {
+	if n, ok := v.num(s, "minimum"); ok && inst < n {
+		v.report(path, "got %v, expected at least %v", inst, n)
+	}
+	if n, ok := v.num(s, "maximum"); ok && inst > n {
+		v.report(path, "got %v, expected at most %v", inst, n)
+	}
+	if n, ok := v.num(s, "exclusiveMinimum"); ok && inst <= n {
+		v.report(path, "got %v, expected more than %v", inst, n)
+	}
+	if n, ok := v.num(s, "exclusiveMaximum"); ok && inst >= n {
+		v.report(path, "got %v, expected less than %v", inst, n)
+	}
+	if n, ok := v.num(s, "multipleOf"); ok {
+		if n <= 0 {
+			v.invalid("multipleOf must be greater than 0, got %v", n)
+		} else if !isMultiple(inst, n) {
+			v.report(path, "got %v, expected a multiple of %v", inst, n)
+		}
+	}
+}
+
+func (v *schemaValidator) combinators(path []pathStep, inst interface{}, s map[string]interface{}) {
+	for _, sub := range v.schemaList(s, "allOf") {
+		v.validate(path, inst, sub)
+	}
+	if anyOf := v.schemaList(s, "anyOf"); anyOf != nil {
+		matched := false
+		for _, sub := range anyOf {
+			if v.valid(path, inst, sub) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.report(path, "doesn't match any schema of anyOf")
+		}
+	}
+	if oneOf := v.schemaList(s, "oneOf"); oneOf != nil {
+		n := 0
+		for _, sub := range oneOf {
+			if v.valid(path, inst, sub) {
+				n++
+			}
+		}
+		if n != 1 {
+			v.report(path, "matches %d schemas of oneOf, expected exactly 1", n)
+		}
+	}
+	if not, ok := s["not"]; ok && v.valid(path, inst, not) {
+		v.report(path, "matches a schema of not")
+	}
+}
+
+// schemaList returns a non-empty array of schemas for a keyword, or nil if it's absent
+func (v *schemaValidator) schemaList(s map[string]interface{}, kw string) []interface{} {
+	x, ok := s[kw]
+	if !ok {
+		return nil
+	}
+	list, ok := x.([]interface{})
+	if !ok || len(list) == 0 {
+		v.invalid("%s must be a non-empty array, got %s", kw, compactJSON(x))
+		return nil
+	}
+	return list
+}
+
+func (v *schemaValidator) schemaMap(s map[string]interface{}, kw string) map[string]interface{} {
+	x, ok := s[kw]
+	if !ok {
+		return nil
+	}
+	m, ok := x.(map[string]interface{})
+	if !ok {
+		v.invalid("%s must be an object, got %s", kw, compactJSON(x))
+	}
+	return m
+}
+
+func (v *schemaValidator) num(s map[string]interface{}, kw string) (float64, bool) {
+	x, ok := s[kw]
+	if !ok {
+		return 0, false
+	}
+	n, ok := x.(float64)
+	if !ok {
+		v.invalid("%s must be a number, got %s", kw, compactJSON(x))
+	}
+	return n, ok
+}
+
+func (v *schemaValidator) count(s map[string]interface{}, kw string) (int, bool) {
+	n, ok := v.num(s, kw)
+	if ok && (n < 0 || n != math.Trunc(n)) {
+		v.invalid("%s must be a non-negative integer, got %v", kw, n)
+		return 0, false
+	}
+	return int(n), ok
+}
+
+func (v *schemaValidator) compile(pattern string) *regexp.Regexp {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		v.invalid("bad pattern %q: %v", pattern, err)
+	}
+	return re
+}
+
+// multipleEpsilon is a relative tolerance of multipleOf, so 0.3 is a multiple of 0.1 despite float rounding
+const multipleEpsilon = 1e-9
+
+func isMultiple(x, n float64) bool {
+	q := x / n
+	return math.Abs(q-math.Round(q)) <= multipleEpsilon*math.Max(1, math.Abs(q))
+}
+
+// jsonType returns a JSON Schema type of a decoded JSON value. Whole numbers are "integer"
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+	}
+	return "number"
+}
@@ -0,0 +1,90 @@
+package fclient_test
+
+import (
+	"testing"
+)
+
+const userSchema = `{
+	"type": "object",
+	"required": ["id", "name", "roles"],
+	"additionalProperties": false,
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"name": {"type": "string", "minLength": 1, "maxLength": 10, "pattern": "^[a-z]+$"},
+		"createdAt": {"type": ["string", "null"]},
+		"roles": {"type": "array", "minItems": 1, "items": {"$ref": "#/$defs/role"}},
+		"tags": {"type": "array", "items": {"enum": ["a", "b"]}}
+	},
+	"$defs": {
+		"role": {
+			"type": "object",
+			"required": ["id"],
+			"properties": {"id": {"type": "number"}, "name": {"const": "admin"}}
+		}
+	}
+}`
+
+func Test_JSONSchema(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, userJSON))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONSchema(`{"type": "object"}`).JSONSchema(`true`).JSONSchema(map[string]interface{}{
+			"properties": map[string]interface{}{"id": map[string]interface{}{"type": "number", "maximum": 7}},
+		})
+	})
+
+	mt.ShouldFail(`$.roles[1].name: got "user", expected "admin"`, func() { cl.Get("/").JSONSchema(userSchema) })
+	mt.ShouldFail(`$: got object {`, func() { cl.Get("/").JSONSchema(`{"type": ["array", "string"]}`) })
+	mt.ShouldFail(`$.email: missing required property`, func() { cl.Get("/").JSONSchema(`{"required": ["email"]}`) })
+	mt.ShouldFail(`$.tags: got 3 items, expected at most 2`, func() { cl.Get("/").JSONSchema(`{"properties": {"tags": {"maxItems": 2}}}`) })
+	mt.ShouldFail(`$.tags[2]: duplicates item 0`, func() { cl.Get("/").JSONSchema(`{"properties": {"tags": {"uniqueItems": true}}}`) })
+	mt.ShouldFail(`$.id: got 7, expected more than 7`, func() { cl.Get("/").JSONSchema(`{"properties": {"id": {"exclusiveMinimum": 7}}}`) })
+	mt.ShouldFail(`$.id: got 7, expected a multiple of 2`, func() { cl.Get("/").JSONSchema(`{"properties": {"id": {"multipleOf": 2}}}`) })
+	mt.ShouldFail(`$.name: "foo" doesn't match pattern "^b"`, func() { cl.Get("/").JSONSchema(`{"properties": {"name": {"pattern": "^b"}}}`) })
+	mt.ShouldFail(`$.name: got length 3, expected at least 4`, func() { cl.Get("/").JSONSchema(`{"properties": {"name": {"minLength": 4}}}`) })
+	mt.ShouldFail(`$.createdAt: additional property isn't allowed`, func() {
+		cl.Get("/").JSONSchema(`{"patternProperties": {"^(id|name|roles|tags)$": true}, "additionalProperties": false}`)
+	})
+	mt.ShouldFail(`$.roles[0]: not allowed by a false schema`, func() {
+		cl.Get("/").JSONSchema(`{"properties": {"roles": {"prefixItems": [false]}}}`)
+	})
+	mt.ShouldFail(`$.id: doesn't match any schema of anyOf`, func() {
+		cl.Get("/").JSONSchema(`{"properties": {"id": {"anyOf": [{"type": "string"}, {"minimum": 10}]}}}`)
+	})
+	mt.ShouldFail(`$.id: matches 2 schemas of oneOf, expected exactly 1`, func() {
+		cl.Get("/").JSONSchema(`{"properties": {"id": {"oneOf": [{"type": "integer"}, {"minimum": 1}]}}}`)
+	})
+	mt.ShouldFail(`$.id: matches a schema of not`, func() { cl.Get("/").JSONSchema(`{"properties": {"id": {"not": {"type": "integer"}}}}`) })
+}
+
+func Test_JSONSchema_multipleOfDecimals(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, `{"price": 0.3, "rate": 19.99, "big": 1e20, "odd": 0.35}`))
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONSchema(`{"properties": {"price": {"multipleOf": 0.1}, "rate": {"multipleOf": 0.01}, "big": {"multipleOf": 0.1}}}`)
+	})
+	mt.ShouldFail(`$.odd: got 0.35, expected a multiple of 0.1`, func() { cl.Get("/").JSONSchema(`{"properties": {"odd": {"multipleOf": 0.1}}}`) })
+}
+
+func Test_JSONSchema_collectsAllViolations(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, `{"id": 0, "name": "FOO", "roles": [], "extra": 1}`))
+	mt.ShouldFail("$.extra: additional property isn't allowed\n"+
+		"$.id: got 0, expected at least 1\n"+
+		`$.name: "FOO" doesn't match pattern "^[a-z]+$"`+"\n"+
+		"$.roles: got 0 items, expected at least 1\n", func() { cl.Get("/").JSONSchema(userSchema) })
+}
+
+func Test_JSONSchema_invalidSchema(t *testing.T) {
+	cl, mt := buildClientMt(t, makeBodyResp(200, userJSON))
+	mt.ShouldFail(`invalid schema: can't resolve $ref "#/$defs/missing"`, func() { cl.Get("/").JSONSchema(`{"$ref": "#/$defs/missing"}`) })
+	mt.ShouldFail(`unsupported $ref "other.json"`, func() { cl.Get("/").JSONSchema(`{"$ref": "other.json"}`) })
+	mt.ShouldFail(`circular $ref "#"`, func() { cl.Get("/").JSONSchema(`{"$ref": "#"}`) })
+	mt.ShouldFail(`unknown type "int"`, func() { cl.Get("/").JSONSchema(`{"type": "int"}`) })
+	mt.ShouldFail(`minLength must be a non-negative integer`, func() {
+		cl.Get("/").JSONSchema(`{"properties": {"name": {"minLength": 1.5}}}`)
+	})
+	mt.ShouldFail(`bad pattern "["`, func() { cl.Get("/").JSONSchema(`{"properties": {"name": {"pattern": "["}}}`) })
+	mt.ShouldFail(`schema must be an object or a boolean, got 1`, func() { cl.Get("/").JSONSchema(`1`) })
+	mt.ShouldFail("argument isn't a valid JSON", func() { cl.Get("/").JSONSchema(`{`) })
+	mt.ShouldPass(func() {
+		cl.Get("/").JSONSchema(`{"$defs": {"a~1b": {"type": "object"}}, "$ref": "#/$defs/a~01b"}`)
+	})
+}
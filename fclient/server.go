@@ -0,0 +1,95 @@
+package fclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+)
+
+// Option configures a Client in New
+type Option func(cl *Client)
+
+// WithServer starts an httptest.Server with the client handler, so requests go through a real network.
+// The server is closed by Client.Close or at the end of the test
+//
+//	cl := fclient.New(t, app, fclient.WithServer())
+func WithServer() Option {
+	return func(cl *Client) { cl.startServer(false, false) }
+}
+
+// WithTLSServer is like WithServer, but the server uses TLS
+func WithTLSServer() Option {
+	return func(cl *Client) { cl.startServer(true, false) }
+}
+
+// WithHTTP2Server is like WithTLSServer, but requests are made with HTTP/2
+func WithHTTP2Server() Option {
+	return func(cl *Client) { cl.startServer(true, true) }
+}
+
+func (cl *Client) startServer(tls, http2 bool) {
+	cl.Close()
+	// cl.Handler is resolved for every request, so it can be replaced after the server is started
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cl.Handler.ServeHTTP(w, r)
+	}))
+	srv.EnableHTTP2 = http2
+	if tls {
+		srv.StartTLS()
+	} else {
+		srv.Start()
+	}
+	cl.Server = srv
+	cl.httpClient = newHTTPClient(srv.Client().Transport)
+	if c, ok := cl.t.(interface{ Cleanup(func()) }); ok {
+		c.Cleanup(cl.Close)
+	}
+}
+
+// Close shuts down the server started by WithServer, WithTLSServer or WithHTTP2Server.
+// Does nothing for a client without a server
+func (cl *Client) Close() {
+	if cl.Server == nil {
+		return
+	}
+	cl.Server.Close()
+	cl.Server, cl.httpClient = nil, nil
+}
+
+// roundTrip sends a request by the http.Client of the client. A path, a query, a method, headers, a body and
+// a Host of req are preserved. The request is sent to the started server, if any, or to the host of req
+func (cl *Client) roundTrip(req *http.Request) *Response {
+	cl.t.Helper()
+	u := requestURL(req)
+	if cl.Server != nil {
+		srv, err := url.Parse(cl.Server.URL)
+		if err != nil {
+			cl.t.Fatalf("Do: bad server URL: %v", err)
+		}
+		u.Scheme, u.Host = srv.Scheme, srv.Host
+	}
+
+	out, err := http.NewRequestWithContext(req.Context(), req.Method, u.String(), req.Body)
+	if err != nil {
+		cl.t.Fatalf("Do: can't build a request: %v", err)
+	}
+	out.Header = req.Header.Clone()
+	out.Host = req.Host
+	out.ContentLength = req.ContentLength
+
+	res, err := cl.httpClient.Do(out)
+	if err != nil {
+		cl.t.Fatalf("Do: %v", err)
+	}
+	return NewResponseFrom(cl.t, res)
+}
+
+// newHTTPClient returns an http.Client which doesn't follow redirects, so the Client can handle them
+func newHTTPClient(rt http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: rt,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package fclient_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alexbyk/ftest"
+	"github.com/alexbyk/ftest/fclient"
+)
+
+func protoHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	http.SetCookie(w, &http.Cookie{Name: "visited", Value: "1"})
+	c, _ := r.Cookie("visited")
+	fmt.Fprintf(w, "%s %s %s %s tls=%v %q %v", r.Proto, r.Method, r.Host, r.RequestURI, r.TLS != nil, body, c)
+}
+
+func Test_WithServer(t *testing.T) {
+	cl := fclient.New(t, http.HandlerFunc(protoHandler), fclient.WithServer())
+	ftest.New(t).NotNil(cl.Server)
+	cl.Post("/x?a=1", "body").CodeEq(200).HeaderContains("Set-Cookie", "visited=1").
+		BodyEq(`HTTP/1.1 POST example.com /x?a=1 tls=false "body" `)
+	cl.Get("/").BodyEq(`HTTP/1.1 GET example.com / tls=false "" visited=1`)
+
+	cl.BaseURL = "http://tenant.example.org"
+	cl.Get("/").BodyEq(`HTTP/1.1 GET tenant.example.org / tls=false "" `)
+
+	cl.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			panic(err)
+		}
+		buf.WriteString("HTTP/1.1 418 I'm a teapot\r\nContent-Length: 6\r\nX-Hijacked: 1\r\n\r\nteapot")
+		buf.Flush()
+		conn.Close()
+	})
+	cl.Get("/").CodeEq(418).HeaderEq("X-Hijacked", "1").BodyEq("teapot")
+
+	cl.Close()
+	ftest.New(t).Nil(cl.Server)
+	cl.Handler = http.HandlerFunc(protoHandler)
+	cl.Get("/").BodyEq(`HTTP/1.1 GET tenant.example.org / tls=false "" visited=1`)
+}
+
+func Test_WithTLSServer(t *testing.T) {
+	cl := fclient.New(t, http.HandlerFunc(protoHandler), fclient.WithTLSServer())
+	cl.Put("/x", "body").BodyEq(`HTTP/1.1 PUT example.com /x tls=true "body" `)
+
+	cl = fclient.New(t, http.HandlerFunc(protoHandler), fclient.WithHTTP2Server())
+	cl.Get("/x").BodyEq(`HTTP/2.0 GET example.com /x tls=true "" `)
+}
+
+func Test_WithServer_redirects(t *testing.T) {
+	cl := fclient.New(t, http.HandlerFunc(redirectHandler), fclient.WithServer())
+	cl.Get("/old").CodeEq(301).RedirectsTo("/login")
+
+	cl.FollowRedirects = true
+	cl.Get("/old").CodeEq(200).RedirectsTo("/dashboard?from=login").
+		BodyEq(`GET example.com /dashboard?from=login "" auth="" cookie="session=secret"`)
+}
@@ -0,0 +1,165 @@
+package fclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxDumpBody is a number of bytes of a body shown in an Exchange dump
+const maxDumpBody = 2048
+
+// defaultMaxHistory is used when Client.MaxHistory is zero
+const defaultMaxHistory = 100
+
+// secretHeaders are headers with credentials, their values are hidden in an Exchange dump
+var secretHeaders = map[string]bool{"Authorization": true, "Proxy-Authorization": true, "Cookie": true, "Set-Cookie": true}
+
+// Exchange is a request made by a Client and its response
+type Exchange struct {
+	Request     *http.Request
+	RequestBody []byte
+	Response    *Response
+}
+
+// String returns a curl-like dump of the request and a raw dump of the response.
+// Long bodies are truncated, credentials in headers are replaced with "<redacted>"
+func (ex *Exchange) String() string {
+	var sb strings.Builder
+	req := ex.Request
+	method := "-X " + req.Method
+	if req.Method == "HEAD" {
+		method = "-I"
+	}
+	fmt.Fprintf(&sb, "request:\ncurl %s %s", method, shellQuote(requestURL(req).String()))
+	for _, k := range sortedHeaderKeys(req.Header) {
+		for _, v := range req.Header[k] {
+			fmt.Fprintf(&sb, " \\\n  -H %s", shellQuote(k+": "+headerValue(k, v)))
+		}
+	}
+	if len(ex.RequestBody) > 0 {
+		fmt.Fprintf(&sb, " \\\n  --data-raw %s", shellQuote(truncateBody(ex.RequestBody)))
+	}
+
+	if ex.Response == nil {
+		return sb.String()
+	}
+	res := ex.Response.Result()
+	proto := res.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	fmt.Fprintf(&sb, "\n\nresponse:\n%s %d %s\n", proto, res.StatusCode, http.StatusText(res.StatusCode))
+	for _, k := range sortedHeaderKeys(res.Header) {
+		for _, v := range res.Header[k] {
+			fmt.Fprintf(&sb, "%s: %s\n", k, headerValue(k, v))
+		}
+	}
+	if ex.Response.Body.Len() > 0 {
+		sb.WriteString("\n" + truncateBody(ex.Response.Body.Bytes()))
+	}
+	return sb.String()
+}
+
+// transcriptT adds a dump of an exchange to failures of Response assertions
+type transcriptT struct {
+	test
+	ex *Exchange
+}
+
+// Name returns a name of the wrapped test, if it has one, so golden files work with the response
+func (tt *transcriptT) Name() string {
+	if n, ok := tt.test.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return ""
+}
+
+func (tt *transcriptT) Errorf(format string, args ...interface{}) {
+	tt.test.Helper()
+	tt.test.Errorf("%s\n\n%s", fmt.Sprintf(format, args...), tt.ex)
+}
+
+func (tt *transcriptT) Fatalf(format string, args ...interface{}) {
+	tt.test.Helper()
+	tt.test.Fatalf("%s\n\n%s", fmt.Sprintf(format, args...), tt.ex)
+}
+
+// record reads a body of req, so it can be shown later, and starts a new exchange
+func (cl *Client) record(req *http.Request) *Exchange {
+	cl.t.Helper()
+	ex := &Exchange{Request: req}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			cl.t.Fatalf("Do: can't read a request body: %v", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		ex.RequestBody = body
+	}
+	return ex
+}
+
+// finish stores an exchange in History, attaches it to failures of the response
+// and logs it if Verbose is set
+func (cl *Client) finish(ex *Exchange, resp *Response) {
+	cl.t.Helper()
+	ex.Response = resp
+	resp.t = &transcriptT{test: cl.t, ex: ex}
+	cl.addHistory(ex)
+	if !cl.Verbose {
+		return
+	}
+	if l, ok := cl.t.(interface{ Logf(string, ...interface{}) }); ok {
+		l.Logf("%s", ex)
+	}
+}
+
+// addHistory appends an exchange to History, dropping the oldest ones above MaxHistory
+func (cl *Client) addHistory(ex *Exchange) {
+	cl.historyMu.Lock()
+	defer cl.historyMu.Unlock()
+	max := cl.MaxHistory
+	if max == 0 {
+		max = defaultMaxHistory
+	}
+	if max < 0 {
+		cl.History = nil
+		return
+	}
+	cl.History = append(cl.History, ex)
+	if n := len(cl.History) - max; n > 0 {
+		cl.History = cl.History[n:]
+	}
+}
+
+func headerValue(key, value string) string {
+	if secretHeaders[key] {
+		return "<redacted>"
+	}
+	return value
+}
+
+func sortedHeaderKeys(h http.Header) []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func truncateBody(body []byte) string {
+	if len(body) <= maxDumpBody {
+		return string(body)
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", body[:maxDumpBody], len(body)-maxDumpBody)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,118 @@
+package fclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alexbyk/ftest"
+	"github.com/alexbyk/ftest/fclient"
+	"github.com/alexbyk/ftest/internal"
+)
+
+type logT struct {
+	*internal.MockT
+	logs []string
+}
+
+func (lt *logT) Logf(format string, args ...interface{}) {
+	lt.logs = append(lt.logs, fmt.Sprintf(format, args...))
+}
+
+func Test_FailureTranscript(t *testing.T) {
+	cl, mt := buildClientMt(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id": 1}`))
+	})
+	mt.ShouldFail("[CodeEq] got: int(201), expected: int(200)\n\n"+
+		"request:\ncurl -X POST 'http://example.com/users?a=b' \\\n"+
+		"  -H 'Content-Type: application/json' \\\n"+
+		"  -H 'X-Req: 1' \\\n"+
+		`  --data-raw '{"name":"it'\''s"}'`+"\n\n"+
+		"response:\nHTTP/1.1 201 Created\nContent-Type: application/json\n\n"+
+		`{"id": 1}`, func() {
+		cl.R().Header("X-Req", "1").Query("a", "b").PostJSON("/users", map[string]string{"name": "it's"}).CodeEq(200)
+	})
+	mt.ShouldFail("JSONPathEq: $.name not found, $ is:", func() { cl.Get("/").JSONPathEq("$.name", "foo") })
+	mt.ShouldFail("response:\nHTTP/1.1 201 Created", func() { cl.Get("/").JSONPathEq("$.name", "foo") })
+
+	cl.Handler = makeBodyResp(200, strings.Repeat("a", 3000))
+	mt.ShouldFail(strings.Repeat("a", 2048)+"... (952 more bytes)", func() { cl.Get("/").BodyEq("") })
+}
+
+func Test_FailureTranscriptRedacts(t *testing.T) {
+	cl, mt := buildClientMt(t, func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+	})
+	mt.ShouldPass(func() { cl.Get("/") })
+	mt.ShouldFail("request:\ncurl -X GET 'http://example.com/' \\\n"+
+		"  -H 'Authorization: <redacted>' \\\n"+
+		"  -H 'Cookie: <redacted>' \\\n"+
+		"  -H 'X-Req: 1'\n\n"+
+		"response:\nHTTP/1.1 200 OK\nSet-Cookie: <redacted>\n", func() {
+		cl.R().BasicAuth("user", "pass").Header("X-Req", "1").Get("/").CodeEq(201)
+	})
+	mt.ShouldFail("request:\ncurl -I 'http://example.com/'", func() { cl.Head("/").CodeEq(201) })
+}
+
+func Test_HistoryConcurrent(t *testing.T) {
+	cl := fclient.New(t, makeBodyResp(200, "hello"))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cl.Get("/")
+		}()
+	}
+	wg.Wait()
+	ftest.New(t).Eq(len(cl.History), 10)
+}
+
+func Test_History(t *testing.T) {
+	cl, mt := buildClientMt(t, redirectHandler)
+	cl.FollowRedirects = true
+	mt.ShouldPass(func() { cl.Post("/old", "data") })
+	mt.ShouldPass(func() { cl.Get("/echo") })
+
+	ass := ftest.New(t)
+	ass.Eq(len(cl.History), 4)
+	ass.Eq(cl.History[0].Request.URL.Path, "/old").Eq(string(cl.History[0].RequestBody), "data").
+		Eq(cl.History[0].Response.Code, 301)
+	ass.Eq(cl.History[2].Request.URL.String(), "/dashboard?from=login").Eq(cl.History[2].Response.Code, 200)
+	ass.Eq(cl.History[3].Request.URL.Path, "/echo")
+	ass.Contains(cl.History[1].String(), "curl -X GET 'http://example.com/login'")
+
+	cl.MaxHistory = 2
+	mt.ShouldPass(func() {
+		cl.Get("/first")
+		cl.Get("/second")
+		cl.Get("/third")
+	})
+	ass.Eq(len(cl.History), 2).Eq(cl.History[0].Request.URL.Path, "/second").Eq(cl.History[1].Request.URL.Path, "/third")
+
+	cl.MaxHistory = -1
+	mt.ShouldPass(func() { cl.Get("/echo") })
+	ass.Eq(len(cl.History), 0)
+}
+
+func Test_FailureTranscriptProto(t *testing.T) {
+	mt := internal.NewMock(t)
+	cl := fclient.New(mt, makeBodyResp(200, "hello"), fclient.WithHTTP2Server())
+	mt.ShouldFail("response:\nHTTP/2.0 200 OK\n", func() { cl.Get("/").BodyEq("") })
+}
+
+func Test_Verbose(t *testing.T) {
+	lt := &logT{MockT: internal.NewMock(t)}
+	cl := fclient.New(lt, makeBodyResp(200, "hello"))
+	lt.ShouldPass(func() { cl.Get("/quiet") })
+	ftest.New(t).Eq(len(lt.logs), 0)
+
+	cl.Verbose = true
+	lt.ShouldPass(func() { cl.Get("/loud") })
+	ftest.New(t).Eq(lt.logs, []string{"request:\ncurl -X GET 'http://example.com/loud'\n\n" +
+		"response:\nHTTP/1.1 200 OK\nContent-Type: text/plain; charset=utf-8\n\nhello"})
+}
@@ -1,6 +1,6 @@
 /*Package ftest is a simple easy to use testing library.
 It prints only the exact location of failed test (without scary stack trace).
-It uses a fluent desing. It stops a test on the first failure
+It uses a fluent desing. It stops a test on the first failure, unless a soft mode is used (see NewSoft)
 
 	ftest.New(t).Eq(2, 2).
 		Contains("FooBarBaz", "Bar").
@@ -8,6 +8,9 @@ It uses a fluent desing. It stops a test on the first failure
 
 Also to make testing simpler, this package performs extra nil checks, so nil, non initialized slice and empty pointer
 - all will be considered equal (and that's what you are expecting)
+
+Integers are compared by value at any depth, so []int64{2} is equal to []int{2}.
+Use MixedNumbers to also match floats with integers, which is handy for decoded JSON
 */
 package ftest
 
@@ -17,9 +20,10 @@ import (
 	"strings"
 )
 
-// Test is an interface with FatalF and Helper methods, which are required by the Client
+// Test is an interface with FatalF, Errorf and Helper methods, which are required by the Client
 type test interface {
 	Fatalf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
 	Helper()
 }
 
@@ -27,8 +31,11 @@ type test interface {
 
 // Assertion represents an assertion which holds current a *testing.T object
 type Assertion struct {
-	t     test
-	label string
+	t            test
+	label        string
+	soft         bool
+	failures     []string
+	mixedNumbers bool
 }
 
 // NewLabel creates an Assertion instance with a label
@@ -41,6 +48,42 @@ func New(t test) *Assertion {
 	return NewLabel(t, "Assertion")
 }
 
+// NewSoft creates an Assertion instance with label "Assertion" in a soft mode
+func NewSoft(t test) *Assertion {
+	return New(t).Soft()
+}
+
+// Soft turns on a soft mode. In this mode a failed assertion doesn't stop the test:
+// every failure is reported by Errorf and collected until Done is invoked
+func (ass *Assertion) Soft() *Assertion {
+	ass.soft = true
+	return ass
+}
+
+// MixedNumbers makes comparisons treat floats and integers holding the same number as equal,
+// so values decoded from JSON, like float64(2), match 2
+func (ass *Assertion) MixedNumbers() *Assertion {
+	ass.mixedNumbers = true
+	return ass
+}
+
+// Failures returns failures collected in a soft mode since the last Done call
+func (ass *Assertion) Failures() []string {
+	return ass.failures
+}
+
+// Done stops the test with a single summary of all failures collected in a soft mode.
+// Does nothing if there were no failures
+func (ass *Assertion) Done() {
+	ass.t.Helper()
+	if len(ass.failures) == 0 {
+		return
+	}
+	failures := ass.failures
+	ass.failures = nil
+	ass.t.Fatalf("[%s] %d assertion(s) failed:\n%s", ass.label, len(failures), strings.Join(failures, "\n"))
+}
+
 // TODO: avoid defer/recover by checking a kind
 func isNil(v interface{}) (ret bool) {
 	defer func() { recover() }()
@@ -54,30 +97,13 @@ func (ass *Assertion) NotEq(got, expected interface{}) *Assertion {
 	return ass.NotEqf(got, expected, "are equal: %v(%v)", reflect.TypeOf(got), got)
 }
 
-func (ass *Assertion) deepEq(got, expected interface{}) bool {
-	ass.t.Helper()
-	gotV := reflect.ValueOf(got)
-	expectedV := reflect.ValueOf(expected)
-	switch {
-	case !gotV.IsValid():
-		return !expectedV.IsValid() || isNil(expected)
-	case !expectedV.IsValid():
-		return !gotV.IsValid() || isNil(got)
-	}
-
-	// Compare values that may be integers to avoid annoying Eq(v, int64(v))
-	// TODO: make this deeply
-	switch gotV := got.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		switch expectedV := expected.(type) {
-		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-			return fmt.Sprintf("%v", gotV) == fmt.Sprintf("%v", expectedV)
-		default:
-			return false // if one is integer, and another isn't, return false
-		}
-	}
-
-	return reflect.DeepEqual(got, expected) || isNil(got) && isNil(expected)
+// deepEq compares values deeply. Integers of different types holding the same number are equal,
+// as well as nil and nil pointers, slices and maps of any type.
+// It doesn't format values, so it's safe to use for passing assertions
+func (ass *Assertion) deepEq(got, expected interface{}, opts ...EqOption) bool {
+	d := ass.newDiffer(1, opts...)
+	d.quiet = true
+	return len(d.diff(got, expected)) == 0
 }
 
 // NotEqf is an f version of NotEq
@@ -89,9 +115,21 @@ func (ass *Assertion) NotEqf(got, expected interface{}, format string, args ...i
 	return ass
 }
 
-// Eq tests if 2 arguments are equal
+// Eq tests if 2 arguments are equal. If they aren't, a failure message
+// also contains every differing path, like `.Users[3].Zip: got "123", expected "124"`
 func (ass *Assertion) Eq(got, expected interface{}) *Assertion {
 	ass.t.Helper()
+	return ass.EqOpts(got, expected)
+}
+
+// EqOpts is like Eq, but compares arguments with given options:
+//
+//	ass.EqOpts(got, expected, ftest.IgnoreFields("ID", "CreatedAt"), ftest.FloatTolerance(1e-9))
+func (ass *Assertion) EqOpts(got, expected interface{}, opts ...EqOption) *Assertion {
+	ass.t.Helper()
+	if ass.deepEq(got, expected, opts...) {
+		return ass
+	}
 	var gotNilS, expNilS string
 	if isNil(got) {
 		gotNilS = "*nil*"
@@ -100,8 +138,10 @@ func (ass *Assertion) Eq(got, expected interface{}) *Assertion {
 		expNilS = "*nil*"
 	}
 
-	return ass.Eqf(got, expected, "got: %v(%s%v), expected: %v(%s%v)",
-		reflect.TypeOf(got), gotNilS, got, reflect.TypeOf(expected), expNilS, expected)
+	ass.fail("got: %v(%s%v), expected: %v(%s%v)%s",
+		reflect.TypeOf(got), gotNilS, got, reflect.TypeOf(expected), expNilS, expected,
+		ass.diffReport(got, expected, opts...))
+	return ass
 }
 
 // Eqf is an f version of Eq
@@ -133,24 +173,18 @@ func (ass *Assertion) Containsf(str, substr, format string, args ...interface{})
 // PanicsSubstr tests if a given function causes and panic
 // and an error contains given substring.
 // You can pass an empty string("") if the error doesn't matter
-func (ass *Assertion) PanicsSubstr(fn func(), substr string) (ret *Assertion) {
-	ass.t.Helper()
-	ret = ass
-
-	defer func() {
-		ass.t.Helper()
-		e := recover()
-		if e == nil {
-			ass.fail("Function didn't panic as expected")
-		}
-		errStr := fmt.Sprintf("%s", e)
-		if !strings.Contains(errStr, substr) {
-			ass.fail("Error \"%s\" doesn't contain substring \"%s\"", errStr, substr)
-		}
-	}()
-
-	fn()
-	return
+func (ass *Assertion) PanicsSubstr(fn func(), substr string) *Assertion {
+	ass.t.Helper()
+	p := catchPanic(fn)
+	if p == nil {
+		ass.fail("Function didn't panic as expected")
+		return ass
+	}
+	errStr := fmt.Sprintf("%s", p.value)
+	if !strings.Contains(errStr, substr) {
+		ass.fail("Error \"%s\" doesn't contain substring \"%s\"\n%s", errStr, substr, p)
+	}
+	return ass
 }
 
 // NotNil tests if a given argument isn't nil
@@ -204,5 +238,10 @@ func (ass *Assertion) Truef(got bool, format string, args ...interface{}) *Asser
 func (ass *Assertion) fail(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	ass.t.Helper()
+	if ass.soft {
+		ass.failures = append(ass.failures, msg)
+		ass.t.Errorf("[%s] %s", ass.label, msg)
+		return
+	}
 	ass.t.Fatalf("[%s] %s", ass.label, msg)
 }
@@ -43,6 +43,15 @@ func Test_Eq(t *testing.T) {
 	mt.ShouldPass(func() { ass.Eq(nil, nilArr) })
 }
 
+func Test_Eq_aliasedSlices(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	type pair struct{ A, B []int }
+	s, e := []int{1, 2}, []int{1, 3}
+	mt.ShouldFail("expected:", func() { ass.Eq(pair{s[:1], s[:2]}, pair{e[:1], e[:2]}) })
+	mt.ShouldFail("expected:", func() { ass.Eq([][]int{s[:1], s[:2]}, [][]int{e[:1], e[:2]}) })
+	mt.ShouldPass(func() { ass.Eq([][]int{s[:1], s[:2]}, [][]int{{1}, {1, 2}}) })
+}
+
 func Test_Eq_int(t *testing.T) {
 	ass, mt := buildAssMt(t)
 	mt.ShouldPass(func() { ass.Eq(2, int64(2)) })
@@ -54,6 +63,33 @@ func Test_Eq_int(t *testing.T) {
 	mt.ShouldFail("string", func() { ass.Eq(33, "33") })
 }
 
+func Test_Eq_deepNumbers(t *testing.T) {
+	type myInt int
+	ass, mt := buildAssMt(t)
+	two := int64(2)
+	mt.ShouldPass(func() { ass.Eq([]int64{2}, []int{2}) })
+	mt.ShouldPass(func() { ass.Eq([1]uint8{2}, [1]int{2}) })
+	mt.ShouldPass(func() { ass.Eq(map[string]int64{"a": 2}, map[string]int{"a": 2}) })
+	mt.ShouldPass(func() { ass.Eq(map[int64]string{2: "a"}, map[int]string{2: "a"}) })
+	mt.ShouldPass(func() {
+		ass.Eq(map[string]interface{}{"a": []interface{}{int32(2)}}, map[string]interface{}{"a": []int{2}})
+	})
+	mt.ShouldPass(func() { ass.Eq(myInt(2), two) })
+	mt.ShouldPass(func() { ass.Eq(&two, func() *int { v := 2; return &v }()) })
+	mt.ShouldPass(func() { ass.Eq(float32(1.5), 1.5) })
+
+	mt.ShouldFail("[0]: got int64(2), expected int(3)", func() { ass.Eq([]int64{2}, []int{3}) })
+	mt.ShouldFail(`[2]: got <none>, expected "a"`, func() { ass.Eq(map[int64]string{}, map[int]string{2: "a"}) })
+	mt.ShouldFail("[0]: got float64(2), expected int(2)", func() { ass.Eq([]float64{2}, []int{2}) })
+	mt.ShouldFail("got: float64(2.5)", func() { ass.MixedNumbers().Eq(2.5, 2) })
+
+	ass, mt = buildAssMt(t)
+	ass.MixedNumbers()
+	mt.ShouldPass(func() { ass.Eq(map[string]interface{}{"id": float64(42)}, map[string]int{"id": 42}) })
+	mt.ShouldPass(func() { ass.Eq(2, float32(2)) })
+	mt.ShouldFail("got: int(-2)", func() { ass.Eq(-2, 2.0) })
+}
+
 func Test_Contains(t *testing.T) {
 	ass, mt := buildAssMt(t)
 	mt.ShouldPass(func() { ass.Contains("Foo", "oo") })
@@ -94,6 +130,20 @@ func Test_TrueFalseNilNotNil(t *testing.T) {
 	mt.ShouldFail("nil", func() { ass.NotNil(inil) })
 }
 
+func Test_Soft(t *testing.T) {
+	mt := internal.NewMock(t)
+	ass := ftest.NewSoft(mt)
+	mt.ShouldFail("Not true", func() { ass.Eq(1, 2).Contains("Foo", "oo").True(false) })
+	ftest.New(t).Eq(len(ass.Failures()), 2)
+	mt.ShouldFail("2 assertion(s) failed:\ngot: int(1)", func() { ass.Done() })
+	ftest.New(t).Eq(len(ass.Failures()), 0)
+	mt.ShouldPass(func() { ass.Done() })
+
+	ass = ftest.New(mt).Soft()
+	mt.ShouldFail("didn't panic", func() { ass.PanicsSubstr(func() {}, "foo") })
+	ftest.New(t).Eq(len(ass.Failures()), 1)
+}
+
 func buildAssMt(t *testing.T) (*ftest.Assertion, *internal.MockT) {
 	mt := internal.NewMock(t)
 	return ftest.New(mt), mt
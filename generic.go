@@ -0,0 +1,113 @@
+package ftest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file contains type-safe versions of assertions. They are functions, because
+// methods can't have type parameters, but they return the same *Assertion to continue a chain:
+//
+//	ftest.EqT(ftest.New(t), got, 22).True(ok)
+
+// EqT is a type-safe version of Eq: a type mismatch is caught at compile time.
+// If T is an interface, uncomparable values it holds, like slices, are compared like in Eq
+func EqT[T comparable](ass *Assertion, got, expected T) *Assertion {
+	ass.t.Helper()
+	if !comparableEq(ass, got, expected) {
+		ass.fail("got: %T(%v), expected: %T(%v)%s", got, got, expected, expected,
+			ass.diffReport(got, expected))
+	}
+	return ass
+}
+
+// NotEqT is a type-safe version of NotEq
+func NotEqT[T comparable](ass *Assertion, got, expected T) *Assertion {
+	ass.t.Helper()
+	if comparableEq(ass, got, expected) {
+		ass.fail("are equal: %T(%v)", got, got)
+	}
+	return ass
+}
+
+// ContainsElem checks if a slice contains a given element
+func ContainsElem[T comparable](ass *Assertion, slice []T, elem T) *Assertion {
+	ass.t.Helper()
+	for _, v := range slice {
+		if comparableEq(ass, v, elem) {
+			return ass
+		}
+	}
+	ass.fail("%v doesn't contain %T(%v)", slice, elem, elem)
+	return ass
+}
+
+// MapHasKey checks if a map has a given key
+func MapHasKey[K comparable, V any](ass *Assertion, m map[K]V, key K) *Assertion {
+	ass.t.Helper()
+	if !hasKey(m, key) {
+		ass.fail("%v doesn't have a key %T(%v)", m, key, key)
+	}
+	return ass
+}
+
+// SliceEqUnordered checks if 2 slices have the same elements, ignoring an order.
+// Duplicates are counted, so [1 1 2] isn't equal to [1 2 2]
+func SliceEqUnordered[T comparable](ass *Assertion, got, expected []T) *Assertion {
+	ass.t.Helper()
+	used := make([]bool, len(expected))
+	var extra []T
+	for _, v := range got {
+		found := false
+		for i, e := range expected {
+			if !used[i] && comparableEq(ass, v, e) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, v)
+		}
+	}
+	var missing []T
+	for i, e := range expected {
+		if !used[i] {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return ass
+	}
+
+	var lines []string
+	if len(missing) > 0 {
+		lines = append(lines, fmt.Sprintf("missing: %v", missing))
+	}
+	if len(extra) > 0 {
+		lines = append(lines, fmt.Sprintf("extra: %v", extra))
+	}
+	ass.fail("got: %v, expected (in any order): %v\n%s", got, expected, strings.Join(lines, "\n"))
+	return ass
+}
+
+// comparableEq compares a and b with ==. If T is an interface holding uncomparable values, like slices,
+// == panics, so such values are compared with deepEq
+func comparableEq[T comparable](ass *Assertion, a, b T) (eq bool) {
+	defer func() {
+		if recover() != nil {
+			eq = ass.deepEq(a, b)
+		}
+	}()
+	return a == b
+}
+
+// hasKey looks up a key in a map. An uncomparable key, held by an interface, can't be in the map
+func hasKey[K comparable, V any](m map[K]V, key K) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	_, ok = m[key]
+	return
+}
@@ -0,0 +1,65 @@
+package ftest_test
+
+import (
+	"testing"
+
+	"github.com/alexbyk/ftest"
+)
+
+func Test_EqT(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ftest.EqT(ass, "foo", "foo").True(true) })
+	mt.ShouldFail(`got: string(foo), expected: string(bar)`, func() { ftest.EqT(ass, "foo", "bar") })
+	mt.ShouldFail(`.Zip: got "1", expected "2"`, func() {
+		ftest.EqT(ass, addressT{Zip: "1"}, addressT{Zip: "2"})
+	})
+
+	mt.ShouldPass(func() { ftest.NotEqT(ass, 1, 2) })
+	mt.ShouldFail("are equal: int(1)", func() { ftest.NotEqT(ass, 1, 1) })
+}
+
+func Test_ContainsElem(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ftest.ContainsElem(ass, []int{1, 2}, 2) })
+	mt.ShouldFail("[1 2] doesn't contain int(3)", func() { ftest.ContainsElem(ass, []int{1, 2}, 3) })
+}
+
+func Test_MapHasKey(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	m := map[string]int{"foo": 0}
+	mt.ShouldPass(func() { ftest.MapHasKey(ass, m, "foo") })
+	mt.ShouldFail("doesn't have a key string(bar)", func() { ftest.MapHasKey(ass, m, "bar") })
+}
+
+func Test_SliceEqUnordered(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ftest.SliceEqUnordered(ass, []int{1, 2, 2}, []int{2, 1, 2}) })
+	mt.ShouldPass(func() { ftest.SliceEqUnordered(ass, nil, []string{}) })
+	mt.ShouldFail("missing: [2]\nextra: [1]", func() {
+		ftest.SliceEqUnordered(ass, []int{1, 1, 2}, []int{1, 2, 2})
+	})
+	mt.ShouldFail("extra: [3]", func() { ftest.SliceEqUnordered(ass, []int{3}, nil) })
+}
+
+func Test_GenericUncomparable(t *testing.T) {
+	// uncomparable values in interfaces make == panic, they are compared deeply instead
+	ass := ftest.New(t)
+	ftest.EqT[interface{}](ass, []int{1}, []int{1})
+	ftest.NotEqT[interface{}](ass, []int{1}, []int{2})
+	ftest.ContainsElem(ass, []interface{}{1, []int{1}}, interface{}([]int{1}))
+	ftest.MapHasKey(ass, map[interface{}]int{1: 1}, 1)
+	ftest.SliceEqUnordered(ass, []interface{}{[]int{1}, "a"}, []interface{}{"a", []int{1}})
+
+	ass, mt := buildAssMt(t)
+	mt.ShouldFail("got: []int([1]), expected: []int([2])", func() { ftest.EqT[interface{}](ass, []int{1}, []int{2}) })
+	mt.ShouldFail("are equal: []int([1])", func() { ftest.NotEqT[interface{}](ass, []int{1}, []int{1}) })
+	mt.ShouldFail("doesn't contain []int([2])", func() {
+		ftest.ContainsElem(ass, []interface{}{[]int{1}}, interface{}([]int{2}))
+	})
+	mt.ShouldFail("doesn't have a key []int([1])", func() {
+		ftest.MapHasKey(ass, map[interface{}]int{1: 1}, interface{}([]int{1}))
+	})
+	mt.ShouldFail("missing: [[2]]\nextra: [[1]]", func() {
+		ftest.SliceEqUnordered(ass, []interface{}{[]int{1}}, []interface{}{[]int{2}})
+	})
+}
@@ -0,0 +1,75 @@
+package ftest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// GoldenEnv is an environment variable, which makes MatchesGolden create or update golden files
+// instead of comparing with them:
+//
+//	FTEST_UPDATE=1 go test ./...
+const GoldenEnv = "FTEST_UPDATE"
+
+// GoldenPath returns a path of a golden file with a given name for the current test:
+// testdata/<TestName>/<name>.golden. The test must have a Name method, like *testing.T
+func (ass *Assertion) GoldenPath(name string) string {
+	ass.t.Helper()
+	testName := nameOf(ass.t)
+	if testName == "" {
+		ass.t.Fatalf("GoldenPath: can't get a name of the test %T", ass.t)
+		return ""
+	}
+	return filepath.Join("testdata", filepath.FromSlash(testName), name+".golden")
+}
+
+// nameOf returns a name of a test, or an empty string if it doesn't have a Name method
+func nameOf(t test) string {
+	if n, ok := t.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return ""
+}
+
+// MatchesGolden checks if got is equal to the content of a golden file (see GoldenPath)
+// and shows a line diff if it isn't.
+// If GoldenEnv is set, the golden file is written with got instead
+func (ass *Assertion) MatchesGolden(name string, got []byte) *Assertion {
+	ass.t.Helper()
+	path := ass.GoldenPath(name)
+	if path == "" {
+		return ass
+	}
+
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			ass.fail("Can't create a golden file: %v", err)
+			return ass
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			ass.fail("Can't write a golden file: %v", err)
+		}
+		return ass
+	}
+
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		ass.fail("Golden file %s doesn't exist, run tests with %s=1 to create it", path, GoldenEnv)
+		return ass
+	}
+	if err != nil {
+		ass.fail("Can't read a golden file: %v", err)
+		return ass
+	}
+	if !bytes.Equal(got, expected) {
+		ass.fail("%s doesn't match:\n%s", path, unifiedDiff(string(expected), string(got)))
+	}
+	return ass
+}
+
+func updateGolden() bool {
+	update, _ := strconv.ParseBool(os.Getenv(GoldenEnv))
+	return update
+}
@@ -0,0 +1,63 @@
+package ftest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexbyk/ftest"
+	"github.com/alexbyk/ftest/internal"
+)
+
+func chdirTemp(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func Test_MatchesGolden(t *testing.T) {
+	chdirTemp(t)
+	ass, mt := buildAssMt(t)
+	ft := ftest.New(t)
+	path := filepath.Join("testdata", "Test_MatchesGolden", "out.golden")
+	ft.Eq(ass.GoldenPath("out"), path)
+
+	mt.ShouldFail("run tests with FTEST_UPDATE=1", func() { ass.MatchesGolden("out", []byte("foo")) })
+
+	t.Setenv(ftest.GoldenEnv, "1")
+	mt.ShouldPass(func() { ass.MatchesGolden("out", []byte("foo\nbar\n")) })
+	content, err := os.ReadFile(path)
+	ft.Nil(err).Eq(string(content), "foo\nbar\n")
+
+	t.Setenv(ftest.GoldenEnv, "")
+	mt.ShouldPass(func() { ass.MatchesGolden("out", []byte("foo\nbar\n")) })
+	mt.ShouldFail("-bar\n+baz", func() { ass.MatchesGolden("out", []byte("foo\nbaz\n")) })
+}
+
+func Test_MatchesGoldenSubtest(t *testing.T) {
+	chdirTemp(t)
+	t.Run("sub", func(t *testing.T) {
+		ftest.New(t).Eq(ftest.New(t).GoldenPath("out"),
+			filepath.Join("testdata", "Test_MatchesGoldenSubtest", "sub", "out.golden"))
+	})
+}
+
+// noNameT is a test without a Name method
+type noNameT struct{ mt *internal.MockT }
+
+func (nt noNameT) Fatalf(format string, args ...interface{}) { nt.mt.Fatalf(format, args...) }
+func (nt noNameT) Errorf(format string, args ...interface{}) { nt.mt.Errorf(format, args...) }
+func (nt noNameT) Helper()                                   {}
+
+func Test_MatchesGoldenWithoutName(t *testing.T) {
+	chdirTemp(t)
+	mt := internal.NewMock(t)
+	ass := ftest.New(noNameT{mt})
+	mt.ShouldPass(func() { ass.Eq(1, 1) })
+	mt.ShouldFail("GoldenPath: can't get a name of the test ftest_test.noNameT", func() { ass.MatchesGolden("out", nil) })
+}
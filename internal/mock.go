@@ -20,12 +20,26 @@ func NewMock(t *testing.T) *MockT { return &MockT{t: t} }
 // Helper Mock
 func (mt *MockT) Helper() {}
 
+// Name returns a name of the wrapped test
+func (mt *MockT) Name() string { return mt.t.Name() }
+
 // Fatalf mock
 func (mt *MockT) Fatalf(format string, args ...interface{}) {
 	mt.err = fmt.Sprintf(format, args...)
 	panic(mt.err)
 }
 
+// Errorf mock. Unlike Fatalf, doesn't stop a function
+func (mt *MockT) Errorf(format string, args ...interface{}) {
+	if mt.err != "" {
+		mt.err += "\n"
+	}
+	mt.err += fmt.Sprintf(format, args...)
+}
+
+// Cleanup registers a function to be called when the wrapped test completes
+func (mt *MockT) Cleanup(fn func()) { mt.t.Cleanup(fn) }
+
 // ShouldFail checks if a passed function fails with a substring
 func (mt *MockT) ShouldFail(substr string, fn func()) {
 	mt.t.Helper()
@@ -0,0 +1,92 @@
+package ftest
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// EqOption customizes a comparison made by EqOpts
+type EqOption func(*eqOptions)
+
+type comparer struct {
+	typ reflect.Type
+	fn  func(a, b interface{}) bool
+}
+
+type eqOptions struct {
+	mixedNumbers     bool
+	ignoreFields     map[string]bool
+	floatTolerance   float64
+	comparers        []comparer
+	ignoreUnexported bool
+}
+
+// IgnoreFields skips struct fields with given names at any depth.
+// A name can also be a dotted path, like "Author.ID", to skip only fields at the end of that path
+func IgnoreFields(names ...string) EqOption {
+	return func(o *eqOptions) {
+		if o.ignoreFields == nil {
+			o.ignoreFields = map[string]bool{}
+		}
+		for _, name := range names {
+			o.ignoreFields[name] = true
+		}
+	}
+}
+
+// FloatTolerance makes floats equal if they differ by no more than epsilon
+func FloatTolerance(epsilon float64) EqOption {
+	return func(o *eqOptions) { o.floatTolerance = epsilon }
+}
+
+// Comparer compares values of type T with a given function instead of a deep comparison.
+// If T is an interface, the function is used for every type that implements it.
+// The function can't be called with values of unexported struct fields, so such values fail
+// the comparison with an error: skip them with IgnoreUnexported or IgnoreFields
+//
+//	ftest.Comparer(func(a, b time.Time) bool { return a.Equal(b) })
+func Comparer[T any](fn func(a, b T) bool) EqOption {
+	c := comparer{
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+		fn:  func(a, b interface{}) bool { return fn(a.(T), b.(T)) },
+	}
+	return func(o *eqOptions) { o.comparers = append(o.comparers, c) }
+}
+
+// IgnoreUnexported skips unexported struct fields
+func IgnoreUnexported() EqOption {
+	return func(o *eqOptions) { o.ignoreUnexported = true }
+}
+
+// comparer returns a custom comparison function for a given pair of values, or nil
+func (o *eqOptions) comparer(got, expected reflect.Value) func(a, b interface{}) bool {
+	if !got.IsValid() || !expected.IsValid() || got.Type() != expected.Type() {
+		return nil
+	}
+	for _, c := range o.comparers {
+		if got.Type() == c.typ || c.typ.Kind() == reflect.Interface && got.Type().Implements(c.typ) {
+			return c.fn
+		}
+	}
+	return nil
+}
+
+func (o *eqOptions) skipField(f reflect.StructField, path string) bool {
+	if o.ignoreUnexported && f.PkgPath != "" {
+		return true
+	}
+	if o.ignoreFields[f.Name] {
+		return true
+	}
+	for name := range o.ignoreFields {
+		if strings.Contains(name, ".") && strings.HasSuffix(path, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *eqOptions) floatEq(a, b float64) bool {
+	return a == b || math.Abs(a-b) <= o.floatTolerance
+}
@@ -0,0 +1,87 @@
+package ftest_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alexbyk/ftest"
+)
+
+type recordT struct {
+	ID        int
+	Name      string
+	Score     float64
+	CreatedAt time.Time
+	Author    *recordAuthorT
+	internal  string
+}
+
+type recordAuthorT struct {
+	ID   int
+	Name string
+}
+
+func Test_EqOptsIgnoreFields(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	got := recordT{ID: 1, Name: "foo", CreatedAt: time.Now(), Author: &recordAuthorT{ID: 10, Name: "a"}}
+	expected := recordT{ID: 2, Name: "foo", Author: &recordAuthorT{ID: 20, Name: "a"}}
+
+	mt.ShouldPass(func() { ass.EqOpts(got, expected, ftest.IgnoreFields("ID", "CreatedAt")) })
+
+	expected.ID = 1
+	mt.ShouldPass(func() { ass.EqOpts(got, expected, ftest.IgnoreFields("CreatedAt", "Author.ID")) })
+	mt.ShouldFail(".Author.ID: got 10, expected 20", func() {
+		ass.EqOpts(got, expected, ftest.IgnoreFields("CreatedAt"))
+	})
+	mt.ShouldFail("[0].Author.ID: got 10, expected 20", func() {
+		ass.EqOpts([]recordT{got}, []recordT{expected}, ftest.IgnoreFields("CreatedAt", "Author.Name"))
+	})
+}
+
+func Test_EqOptsFloatTolerance(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	a, b := 0.1, 0.2
+	mt.ShouldPass(func() { ass.EqOpts(a+b, 0.3, ftest.FloatTolerance(1e-9)) })
+	mt.ShouldPass(func() { ass.EqOpts([]float32{1}, []float64{1.0000001}, ftest.FloatTolerance(1e-6)) })
+	mt.ShouldFail("got: float64(0.30000000000000004)", func() { ass.Eq(a+b, 0.3) })
+	mt.ShouldFail(".Score: got 1.5, expected 1.6", func() {
+		ass.EqOpts(recordT{Score: 1.5}, recordT{Score: 1.6}, ftest.FloatTolerance(0.01))
+	})
+}
+
+func Test_EqOptsComparer(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	now := time.Now()
+	byUnix := ftest.Comparer(func(a, b time.Time) bool { return a.Unix() == b.Unix() })
+	got := recordT{CreatedAt: now}
+	expected := recordT{CreatedAt: now.Truncate(time.Second)}
+
+	mt.ShouldPass(func() { ass.EqOpts(got, expected, byUnix) })
+	mt.ShouldFail(".CreatedAt: got", func() {
+		ass.EqOpts(got, recordT{CreatedAt: now.Add(time.Hour)}, byUnix)
+	})
+
+	byString := ftest.Comparer(func(a, b fmt.Stringer) bool { return a.String() == b.String() })
+	mt.ShouldPass(func() {
+		ass.EqOpts([]interface{}{time.Second}, []interface{}{1000 * time.Millisecond}, byString)
+	})
+
+	type privateT struct{ created time.Time }
+	mt.ShouldFail(".created: Comparer for time.Time can't be applied to an unexported field", func() {
+		ass.EqOpts(privateT{now}, privateT{now.Round(0).UTC()}, ftest.Comparer(time.Time.Equal))
+	})
+	mt.ShouldFail(".created: Comparer for time.Time can't be applied", func() {
+		ass.EqOpts(privateT{now}, privateT{now}, ftest.Comparer(time.Time.Equal))
+	})
+}
+
+func Test_EqOptsIgnoreUnexported(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() {
+		ass.EqOpts(recordT{internal: "a"}, recordT{internal: "b"}, ftest.IgnoreUnexported())
+	})
+	mt.ShouldFail(`.internal: got "a", expected "b"`, func() {
+		ass.EqOpts(recordT{internal: "a"}, recordT{internal: "b"})
+	})
+}
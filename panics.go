@@ -0,0 +1,106 @@
+package ftest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// panicInfo holds a recovered value and a stack of the goroutine at the moment of a panic
+type panicInfo struct {
+	value interface{}
+	stack []byte
+}
+
+func (p *panicInfo) String() string {
+	return fmt.Sprintf("panic value: %T(%v)\npanic site:\n%s", p.value, p.value, panicSite(p.stack))
+}
+
+// catchPanic invokes fn and returns an information about a panic, or nil if fn didn't panic
+func catchPanic(fn func()) (p *panicInfo) {
+	panicked := true
+	defer func() {
+		if panicked {
+			p = &panicInfo{value: recover(), stack: debug.Stack()}
+		}
+	}()
+	fn()
+	panicked = false
+	return nil
+}
+
+// panicSite cuts frames of the recovering code from a stack trace, so it contains only frames
+// from the panic call site to the tested function
+func panicSite(stack []byte) []byte {
+	i := bytes.Index(stack, []byte("\npanic("))
+	if i < 0 {
+		return stack
+	}
+	rest := stack[i+1:]
+	for n := 0; n < 2; n++ {
+		nl := bytes.IndexByte(rest, '\n')
+		if nl < 0 {
+			return stack
+		}
+		rest = rest[nl+1:]
+	}
+	if i := bytes.Index(rest, []byte("ftest.catchPanic(")); i >= 0 {
+		rest = rest[:bytes.LastIndexByte(rest[:i], '\n')+1]
+	}
+	return bytes.TrimRight(rest, "\n")
+}
+
+// NotPanics tests if a given function doesn't panic
+func (ass *Assertion) NotPanics(fn func()) *Assertion {
+	ass.t.Helper()
+	if p := catchPanic(fn); p != nil {
+		ass.fail("Function panicked unexpectedly\n%s", p)
+	}
+	return ass
+}
+
+// PanicsWithValue tests if a given function panics with a value equal to expected
+func (ass *Assertion) PanicsWithValue(fn func(), expected interface{}) *Assertion {
+	ass.t.Helper()
+	p := catchPanic(fn)
+	switch {
+	case p == nil:
+		ass.fail("Function didn't panic as expected")
+	case !ass.deepEq(p.value, expected):
+		ass.fail("Panic value isn't equal to %T(%v)\n%s", expected, expected, p)
+	}
+	return ass
+}
+
+// PanicsWithError tests if a given function panics with an error, which matches target (see errors.Is)
+func (ass *Assertion) PanicsWithError(fn func(), target error) *Assertion {
+	ass.t.Helper()
+	p := catchPanic(fn)
+	if p == nil {
+		ass.fail("Function didn't panic as expected")
+		return ass
+	}
+	if err, ok := p.value.(error); !ok || !errors.Is(err, target) {
+		ass.fail("Panic value isn't %T(%v)\n%s", target, target, p)
+	}
+	return ass
+}
+
+// PanicsMatch tests if a given function panics with a value, which matches a regular expression
+// being formatted as a string
+func (ass *Assertion) PanicsMatch(fn func(), pattern string) *Assertion {
+	ass.t.Helper()
+	re := ass.compile(pattern)
+	if re == nil {
+		return ass
+	}
+	p := catchPanic(fn)
+	switch {
+	case p == nil:
+		ass.fail("Function didn't panic as expected")
+	case !re.MatchString(fmt.Sprint(p.value)):
+		ass.fail("Panic value doesn't match /%s/\n%s", pattern, p)
+	}
+	return ass
+}
@@ -0,0 +1,45 @@
+package ftest_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+func panicNotFound() {
+	panic(fmt.Errorf("load: %w", errNotFound))
+}
+
+func Test_NotPanics(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.NotPanics(func() {}) })
+	mt.ShouldFail("panic value: *fmt.wrapError(load: not found)", func() { ass.NotPanics(panicNotFound) })
+	mt.ShouldFail("panic site:\ngithub.com/alexbyk/ftest_test.panicNotFound()", func() { ass.NotPanics(panicNotFound) })
+}
+
+func Test_PanicsWithValue(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.PanicsWithValue(func() { panic(int64(2)) }, 2) })
+	mt.ShouldPass(func() { ass.PanicsWithValue(func() { panic([]string{"a"}) }, []string{"a"}) })
+	mt.ShouldFail("didn't panic", func() { ass.PanicsWithValue(func() {}, 2) })
+	mt.ShouldFail("isn't equal to int(3)\npanic value: int(2)", func() {
+		ass.PanicsWithValue(func() { panic(2) }, 3)
+	})
+}
+
+func Test_PanicsWithError(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.PanicsWithError(panicNotFound, errNotFound) })
+	mt.ShouldFail("didn't panic", func() { ass.PanicsWithError(func() {}, errNotFound) })
+	mt.ShouldFail("panic value: string(not found)", func() {
+		ass.PanicsWithError(func() { panic("not found") }, errNotFound)
+	})
+}
+
+func Test_PanicsMatch(t *testing.T) {
+	ass, mt := buildAssMt(t)
+	mt.ShouldPass(func() { ass.PanicsMatch(panicNotFound, `^load: not`) })
+	mt.ShouldPass(func() { ass.PanicsMatch(func() { panic(42) }, `^\d+$`) })
+	mt.ShouldFail("didn't panic", func() { ass.PanicsMatch(func() {}, "") })
+	mt.ShouldFail("doesn't match /^not/", func() { ass.PanicsMatch(panicNotFound, `^not`) })
+	mt.ShouldFail("Bad regexp", func() { ass.PanicsMatch(panicNotFound, `(`) })
+}